@@ -0,0 +1,221 @@
+package coll
+
+import (
+	"iter"
+	"slices"
+)
+
+// Bag is a collection that counts occurrences of its elements, also known as a multiset.
+// Distinct values are kept in the order they were first added.
+type Bag[V comparable] interface {
+	Ordered[V]
+
+	// Add adds n occurrences of the given value. Does nothing if n is less than 1.
+	Add(v V, n int)
+
+	// Clear removes all elements from the bag.
+	Clear()
+
+	// Contains returns true if the bag contains at least one occurrence of the given value.
+	Contains(v V) bool
+
+	// Count returns the number of occurrences of the given value.
+	Count(v V) int
+
+	// Distinct returns an iterator over distinct values in the bag.
+	Distinct() iter.Seq[V]
+
+	// DistinctLen returns the number of distinct values in the bag.
+	DistinctLen() int
+
+	// Intersection returns a new bag where each value occurs the minimum number of times it occurs in either bag.
+	Intersection(other Bag[V]) Bag[V]
+
+	// MostCommon returns a Map of the k most common values and their counts, ordered from the most common.
+	// Values with equal counts keep their original order. If k is negative, all values are returned.
+	MostCommon(k int) Map[V, int]
+
+	// Remove removes up to n occurrences of the given value.
+	// Returns the number of removed items.
+	Remove(v V, n int) (removed int)
+
+	// Sum returns a new bag where each value occurs the total number of times it occurs in both bags.
+	Sum(other Bag[V]) Bag[V]
+
+	// Union returns a new bag where each value occurs the maximum number of times it occurs in either bag.
+	Union(other Bag[V]) Bag[V]
+}
+
+type comfyBag[V comparable] struct {
+	s    []V
+	kp   map[V]int
+	vc   *valuesCounter[V]
+	size int
+}
+
+// NewBag creates a new Bag instance.
+func NewBag[V comparable]() Bag[V] {
+	return &comfyBag[V]{
+		s:  []V(nil),
+		kp: make(map[V]int),
+		vc: newValuesCounter[V](),
+	}
+}
+
+// NewBagFrom creates a new Bag instance from a slice, counting each occurrence of a value.
+func NewBagFrom[V comparable](s []V) Bag[V] {
+	b := NewBag[V]()
+	for _, v := range s {
+		b.Add(v, 1)
+	}
+	return b
+}
+
+func (c *comfyBag[V]) Add(v V, n int) {
+	if n < 1 {
+		return
+	}
+	if _, exists := c.kp[v]; !exists {
+		c.kp[v] = len(c.s)
+		c.s = append(c.s, v)
+	}
+	c.vc.Set(v, c.vc.Count(v)+n)
+	c.size += n
+}
+
+func (c *comfyBag[V]) Clear() {
+	c.s = []V(nil)
+	c.kp = make(map[V]int)
+	c.vc = newValuesCounter[V]()
+	c.size = 0
+}
+
+func (c *comfyBag[V]) Contains(v V) bool {
+	return c.vc.Count(v) > 0
+}
+
+func (c *comfyBag[V]) Count(v V) int {
+	return c.vc.Count(v)
+}
+
+func (c *comfyBag[V]) Distinct() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range c.s {
+			if !yield(v) {
+				break
+			}
+		}
+	}
+}
+
+func (c *comfyBag[V]) DistinctLen() int {
+	return len(c.s)
+}
+
+func (c *comfyBag[V]) Intersection(other Bag[V]) Bag[V] {
+	intersection := NewBag[V]()
+	for _, v := range c.s {
+		intersection.Add(v, min(c.vc.Count(v), other.Count(v)))
+	}
+	return intersection
+}
+
+func (c *comfyBag[V]) IsEmpty() bool {
+	return c.size == 0
+}
+
+func (c *comfyBag[V]) Len() int {
+	return c.size
+}
+
+func (c *comfyBag[V]) MostCommon(k int) Map[V, int] {
+	sorted := slices.Clone(c.s)
+	slices.SortStableFunc(sorted, func(a, b V) int {
+		return c.vc.Count(b) - c.vc.Count(a)
+	})
+	if k >= 0 && k < len(sorted) {
+		sorted = sorted[:k]
+	}
+
+	mostCommon := NewMap[V, int]()
+	for _, v := range sorted {
+		mostCommon.Set(v, c.vc.Count(v))
+	}
+	return mostCommon
+}
+
+func (c *comfyBag[V]) Remove(v V, n int) (removed int) {
+	count := c.vc.Count(v)
+	if n < 1 || count == 0 {
+		return 0
+	}
+
+	removed = min(n, count)
+	c.vc.Set(v, count-removed)
+	c.size -= removed
+	if removed == count {
+		c.removeDistinct(v)
+	}
+	return removed
+}
+
+func (c *comfyBag[V]) Sum(other Bag[V]) Bag[V] {
+	sum := c.copy().(*comfyBag[V])
+	for v := range other.Distinct() {
+		sum.Add(v, other.Count(v))
+	}
+	return sum
+}
+
+func (c *comfyBag[V]) Union(other Bag[V]) Bag[V] {
+	union := c.copy().(*comfyBag[V])
+	for v := range other.Distinct() {
+		union.Add(v, other.Count(v)-union.Count(v))
+	}
+	return union
+}
+
+func (c *comfyBag[V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range c.s {
+			for range c.vc.Count(v) {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (c *comfyBag[V]) ValuesRev() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for i := len(c.s) - 1; i >= 0; i-- {
+			for range c.vc.Count(c.s[i]) {
+				if !yield(c.s[i]) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Private:
+
+//nolint:unused
+func (c *comfyBag[V]) copy() baseInternal[V] {
+	newBag := NewBag[V]().(*comfyBag[V])
+	for _, v := range c.s {
+		newBag.Add(v, c.vc.Count(v))
+	}
+	return newBag
+}
+
+func (c *comfyBag[V]) removeDistinct(v V) {
+	pos, exists := c.kp[v]
+	if !exists {
+		return
+	}
+
+	_, c.s, _ = sliceRemoveAt(c.s, pos)
+	c.kp = comfyMakeKeyPosMap(c.s)
+}
@@ -0,0 +1,257 @@
+package coll
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+type comfyBagIntBuilder[C baseInternal[int]] struct {
+}
+
+func (lcb *comfyBagIntBuilder[C]) Empty() C {
+	return any(lcb.make([]int(nil))).(C)
+}
+
+func (lcb *comfyBagIntBuilder[C]) One() C {
+	return any(lcb.make([]int{111})).(C)
+}
+
+func (lcb *comfyBagIntBuilder[C]) Two() C {
+	return any(lcb.make([]int{123, 234})).(C)
+}
+
+func (lcb *comfyBagIntBuilder[C]) Three() C {
+	return any(lcb.make([]int{111, 222, 333})).(C)
+}
+
+func (lcb *comfyBagIntBuilder[C]) ThreeRev() C {
+	return any(lcb.make([]int{333, 222, 111})).(C)
+}
+
+func (lcb *comfyBagIntBuilder[C]) SixWithDuplicates() C {
+	return any(lcb.make([]int{111, 222, 333, 111, 222, 333})).(C)
+}
+
+func (lcb *comfyBagIntBuilder[C]) FromValues(values []any) C {
+	c := lcb.make([]int{})
+	for _, v := range values {
+		c.Add(v.(int), 1)
+	}
+	return any(c).(C)
+}
+
+func (lcb *comfyBagIntBuilder[C]) make(items []int) *comfyBag[int] {
+	return NewBagFrom(items).(*comfyBag[int])
+}
+
+func (lcb *comfyBagIntBuilder[C]) extractRawValues(c C) any {
+	return lcb.extractUnderlyingSlice(c)
+}
+
+func (lcb *comfyBagIntBuilder[C]) extractUnderlyingSlice(c C) any {
+	return slices.Collect((any(c)).(*comfyBag[int]).Values())
+}
+
+func (lcb *comfyBagIntBuilder[C]) extractUnderlyingMap(_ C) any {
+	return nil
+}
+
+func (lcb *comfyBagIntBuilder[C]) extractUnderlyingKp(c C) any {
+	return (any(c)).(*comfyBag[int]).kp
+}
+
+func (lcb *comfyBagIntBuilder[C]) extractUnderlyingValsCount(c C) any {
+	return (any(c)).(*comfyBag[int]).vc.counter
+}
+
+func TestNewBag(t *testing.T) {
+	bag := NewBag[string]()
+	if bag == nil {
+		t.Error("NewBag[string]() returned nil")
+	}
+	if !reflect.DeepEqual(bag, &comfyBag[string]{
+		s:  []string(nil),
+		kp: make(map[string]int),
+		vc: newValuesCounter[string](),
+	}) {
+		t.Error("NewBag[string]() did not return a comfyBag[string]")
+	}
+}
+
+func TestNewBagFrom(t *testing.T) {
+	bag := NewBagFrom([]string{"b", "a", "b", "c", "b"})
+	want := &comfyBag[string]{
+		s:    []string{"b", "a", "c"},
+		kp:   map[string]int{"b": 0, "a": 1, "c": 2},
+		vc:   &valuesCounter[string]{counter: map[string]int{"b": 3, "a": 1, "c": 1}},
+		size: 5,
+	}
+	if !reflect.DeepEqual(bag, want) {
+		t.Errorf("NewBagFrom() = %v, want %v", bag, want)
+	}
+}
+
+func Test_comfyBag_Add(t *testing.T) {
+	bag := NewBag[string]()
+	bag.Add("a", 2)
+	bag.Add("b", 0)
+	bag.Add("c", -1)
+	bag.Add("a", 1)
+
+	if got := bag.Count("a"); got != 3 {
+		t.Errorf("Count(a) = %v, want %v", got, 3)
+	}
+	if bag.Contains("b") || bag.Contains("c") {
+		t.Error("Add() with non-positive count added a value")
+	}
+	if bag.Len() != 3 || bag.DistinctLen() != 1 {
+		t.Errorf("Len() = %v, DistinctLen() = %v, want 3 and 1", bag.Len(), bag.DistinctLen())
+	}
+}
+
+func Test_comfyBag_Clear(t *testing.T) {
+	bag := NewBagFrom([]int{1, 1, 2})
+	bag.Clear()
+	if !reflect.DeepEqual(bag, NewBag[int]()) {
+		t.Errorf("Clear() resulted in: %v", bag)
+	}
+}
+
+func Test_comfyBag_Distinct(t *testing.T) {
+	bag := NewBagFrom([]int{3, 1, 3, 2, 1})
+	if got := slices.Collect(bag.Distinct()); !reflect.DeepEqual(got, []int{3, 1, 2}) {
+		t.Errorf("Distinct() = %v, want %v", got, []int{3, 1, 2})
+	}
+}
+
+func Test_comfyBag_IsEmpty(t *testing.T) {
+	testIsEmpty(t, &comfyBagIntBuilder[baseInternal[int]]{})
+}
+
+func Test_comfyBag_Len(t *testing.T) {
+	testLen(t, &comfyBagIntBuilder[baseInternal[int]]{})
+}
+
+func Test_comfyBag_MostCommon(t *testing.T) {
+	bag := NewBagFrom([]string{"a", "b", "c", "b", "c", "d", "c"})
+	cases := []struct {
+		name string
+		k    int
+		want []Pair[string, int]
+	}{
+		{name: "MostCommon(0)", k: 0, want: []Pair[string, int](nil)},
+		{name: "MostCommon(1)", k: 1, want: []Pair[string, int]{NewPair("c", 3)}},
+		{name: "MostCommon(3) keeps order of ties", k: 3, want: []Pair[string, int]{
+			NewPair("c", 3), NewPair("b", 2), NewPair("a", 1),
+		}},
+		{name: "MostCommon(-1) returns all", k: -1, want: []Pair[string, int]{
+			NewPair("c", 3), NewPair("b", 2), NewPair("a", 1), NewPair("d", 1),
+		}},
+		{name: "MostCommon(10) returns all", k: 10, want: []Pair[string, int]{
+			NewPair("c", 3), NewPair("b", 2), NewPair("a", 1), NewPair("d", 1),
+		}},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slices.Collect(bag.MostCommon(tt.k).Values())
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MostCommon() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_comfyBag_Remove(t *testing.T) {
+	cases := []struct {
+		name      string
+		n         int
+		value     int
+		want1     int
+		want2     []int
+		wantCount int
+	}{
+		{name: "Remove() some occurrences", value: 1, n: 2, want1: 2, want2: []int{1, 2, 3}, wantCount: 1},
+		{name: "Remove() all occurrences", value: 1, n: 3, want1: 3, want2: []int{2, 3}, wantCount: 0},
+		{name: "Remove() more than present", value: 2, n: 5, want1: 1, want2: []int{1, 3}, wantCount: 0},
+		{name: "Remove() missing value", value: 9, n: 1, want1: 0, want2: []int{1, 2, 3}, wantCount: 0},
+		{name: "Remove() zero occurrences", value: 1, n: 0, want1: 0, want2: []int{1, 2, 3}, wantCount: 3},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			bag := NewBagFrom([]int{1, 2, 1, 3, 1}).(*comfyBag[int])
+			lenBefore := bag.Len()
+			if got := bag.Remove(tt.value, tt.n); got != tt.want1 {
+				t.Errorf("Remove() = %v, want %v", got, tt.want1)
+			}
+			if !reflect.DeepEqual(bag.s, tt.want2) {
+				t.Errorf("Remove() left distinct values %v, want %v", bag.s, tt.want2)
+			}
+			if !reflect.DeepEqual(bag.kp, comfyMakeKeyPosMap(tt.want2)) {
+				t.Errorf("Remove() resulted in inconsistent positions: %v", bag.kp)
+			}
+			if got := bag.Count(tt.value); got != tt.wantCount {
+				t.Errorf("Count() after Remove() = %v, want %v", got, tt.wantCount)
+			}
+			if bag.Len() != lenBefore-tt.want1 {
+				t.Errorf("Len() after Remove() = %v, want %v", bag.Len(), lenBefore-tt.want1)
+			}
+		})
+	}
+}
+
+func Test_comfyBag_arithmetic(t *testing.T) {
+	a := NewBagFrom([]string{"x", "x", "y", "z", "z", "z"})
+	b := NewBagFrom([]string{"w", "z", "x", "x", "x"})
+
+	cases := []struct {
+		name string
+		got  Bag[string]
+		want []string
+	}{
+		{name: "Sum()", got: a.Sum(b), want: []string{"x", "x", "x", "x", "x", "y", "z", "z", "z", "z", "w"}},
+		{name: "Intersection()", got: a.Intersection(b), want: []string{"x", "x", "z"}},
+		{name: "Union()", got: a.Union(b), want: []string{"x", "x", "x", "y", "z", "z", "z", "w"}},
+		{name: "Intersection() with empty", got: a.Intersection(NewBag[string]()), want: []string(nil)},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := slices.Collect(tt.got.Values()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+			}
+			if tt.got.Len() != len(tt.want) {
+				t.Errorf("%s Len() = %v, want %v", tt.name, tt.got.Len(), len(tt.want))
+			}
+		})
+	}
+
+	t.Run("operands are not modified", func(t *testing.T) {
+		if a.Len() != 6 || b.Len() != 5 {
+			t.Errorf("operands were modified: %v, %v", a.Len(), b.Len())
+		}
+	})
+}
+
+func Test_comfyBag_Values(t *testing.T) {
+	testValues(t, &comfyBagIntBuilder[baseInternal[int]]{})
+	testValuesBreak(t, &comfyBagIntBuilder[baseInternal[int]]{})
+}
+
+func Test_comfyBag_ValuesRev(t *testing.T) {
+	testValuesRev(t, &comfyBagIntBuilder[orderedInternal[int]]{})
+	testValuesRevBreak(t, &comfyBagIntBuilder[orderedInternal[int]]{})
+}
+
+func Test_comfyBag_copy(t *testing.T) {
+	testCopy(t, &comfyBagIntBuilder[baseInternal[int]]{})
+}
+
+func Test_comfyBag_copy_pointer(t *testing.T) {
+	c1 := NewBagFrom([]int{1, 2, 2})
+	c2 := Copy(c1)
+
+	c1.Add(2, 5)
+	if c2.Count(2) != 2 {
+		t.Error("copy() did not create a deep copy")
+	}
+}
@@ -0,0 +1,329 @@
+package coll
+
+import (
+	"iter"
+	"slices"
+)
+
+// DuplicateValuePolicy defines what a BiMap does when a value is set that is already associated with another key.
+type DuplicateValuePolicy int
+
+const (
+	// DuplicateValueReject makes the BiMap refuse the write with ErrDuplicateValue.
+	DuplicateValueReject DuplicateValuePolicy = iota
+
+	// DuplicateValueEvict makes the BiMap remove the key previously associated with the value.
+	DuplicateValueEvict
+)
+
+// BiMap is a Map in which values are unique, so that keys can be looked up by value in O(1).
+//
+// Pairs yielded by Values, ValuesRev and At are copies: changing them with SetVal does not modify the BiMap,
+// because it would bypass the uniqueness check. Use Set or Put instead.
+//
+// Map methods that cannot return an error (Set, SetMany, Append, Prepend and Apply) silently skip pairs
+// rejected by the DuplicateValueReject policy.
+type BiMap[K, V comparable] interface {
+	Map[K, V]
+
+	// GetKey returns the key associated with the given value.
+	GetKey(v V) (K, bool)
+
+	// HasValue returns true if the given value is present in the map.
+	HasValue(v V) bool
+
+	// Inverse returns a live view of the map with keys and values swapped.
+	// Changes made through the view are visible in the original map and vice versa.
+	// The returned Map is a BiMap[V, K] sharing the policy of the original.
+	Inverse() Map[V, K]
+
+	// Put sets the value associated with the given key.
+	// Returns ErrDuplicateValue if the value is already associated with another key
+	// and the policy is DuplicateValueReject.
+	Put(k K, v V) error
+}
+
+type comfyBiMap[K, V comparable] struct {
+	fwd    *comfyMap[K, V]
+	bwd    *comfyMap[V, K]
+	policy DuplicateValuePolicy
+}
+
+// NewBiMap creates a new BiMap instance with the given duplicate value policy.
+func NewBiMap[K, V comparable](policy DuplicateValuePolicy) BiMap[K, V] {
+	return &comfyBiMap[K, V]{
+		fwd:    NewMap[K, V]().(*comfyMap[K, V]),
+		bwd:    NewMap[V, K]().(*comfyMap[V, K]),
+		policy: policy,
+	}
+}
+
+// NewBiMapFrom creates a new BiMap instance from a slice of pairs.
+// Pairs rejected by the policy are skipped.
+func NewBiMapFrom[K, V comparable](s []Pair[K, V], policy DuplicateValuePolicy) BiMap[K, V] {
+	bm := NewBiMap[K, V](policy)
+	bm.SetMany(s)
+	return bm
+}
+
+func (c *comfyBiMap[K, V]) Append(p ...Pair[K, V]) {
+	c.moveKeys(c.putAll(p), false)
+}
+
+func (c *comfyBiMap[K, V]) AppendColl(coll Ordered[Pair[K, V]]) {
+	c.Append(slices.Collect(coll.Values())...)
+}
+
+func (c *comfyBiMap[K, V]) Apply(f Mapper[Pair[K, V]]) {
+	mapped := make([]Pair[K, V], 0, len(c.fwd.s))
+	for _, pair := range c.fwd.s {
+		mapped = append(mapped, f(pair.copy()))
+	}
+	c.Clear()
+	c.putAll(mapped)
+}
+
+func (c *comfyBiMap[K, V]) At(i int) (p Pair[K, V], found bool) {
+	if p, found = c.fwd.At(i); found {
+		p = p.copy()
+	}
+	return p, found
+}
+
+func (c *comfyBiMap[K, V]) AtOrDefault(i int, defaultValue Pair[K, V]) Pair[K, V] {
+	if p, found := c.At(i); found {
+		return p
+	}
+	return defaultValue
+}
+
+func (c *comfyBiMap[K, V]) Clear() {
+	c.fwd.Clear()
+	c.bwd.Clear()
+}
+
+func (c *comfyBiMap[K, V]) Get(k K) (V, bool) {
+	return c.fwd.Get(k)
+}
+
+func (c *comfyBiMap[K, V]) GetKey(v V) (K, bool) {
+	return c.bwd.Get(v)
+}
+
+func (c *comfyBiMap[K, V]) GetOrDefault(k K, defaultValue V) V {
+	return c.fwd.GetOrDefault(k, defaultValue)
+}
+
+func (c *comfyBiMap[K, V]) Has(k K) bool {
+	return c.fwd.Has(k)
+}
+
+func (c *comfyBiMap[K, V]) HasValue(v V) bool {
+	return c.bwd.Has(v)
+}
+
+func (c *comfyBiMap[K, V]) Inverse() Map[V, K] {
+	return &comfyBiMap[V, K]{
+		fwd:    c.bwd,
+		bwd:    c.fwd,
+		policy: c.policy,
+	}
+}
+
+func (c *comfyBiMap[K, V]) IsEmpty() bool {
+	return c.fwd.IsEmpty()
+}
+
+func (c *comfyBiMap[K, V]) Keys() iter.Seq[K] {
+	return c.fwd.Keys()
+}
+
+func (c *comfyBiMap[K, V]) KeyValues() iter.Seq2[K, V] {
+	return c.fwd.KeyValues()
+}
+
+func (c *comfyBiMap[K, V]) Len() int {
+	return c.fwd.Len()
+}
+
+func (c *comfyBiMap[K, V]) Prepend(p ...Pair[K, V]) {
+	c.moveKeys(c.putAll(p), true)
+}
+
+func (c *comfyBiMap[K, V]) Put(k K, v V) error {
+	if oldV, ok := c.fwd.Get(k); ok && oldV == v {
+		return nil
+	}
+
+	if otherK, ok := c.bwd.Get(v); ok {
+		if c.policy == DuplicateValueReject {
+			return ErrDuplicateValue
+		}
+		c.Remove(otherK)
+	}
+
+	oldV, exists := c.fwd.Get(k)
+	c.fwd.set(NewPair(k, v))
+	if !exists {
+		c.bwd.set(NewPair(v, k))
+		return nil
+	}
+
+	// Replace the old value in place, so that both maps keep the same order.
+	pos := c.bwd.kp[oldV]
+	delete(c.bwd.m, oldV)
+	delete(c.bwd.kp, oldV)
+	pair := NewPair(v, k)
+	c.bwd.s[pos] = pair
+	c.bwd.m[v] = pair
+	c.bwd.kp[v] = pos
+
+	return nil
+}
+
+func (c *comfyBiMap[K, V]) Remove(k K) {
+	v, ok := c.fwd.Get(k)
+	if !ok {
+		return
+	}
+	c.fwd.remove(k)
+	c.bwd.remove(v)
+}
+
+func (c *comfyBiMap[K, V]) RemoveAt(idx int) (removed Pair[K, V], err error) {
+	removed, found := c.fwd.At(idx)
+	if !found {
+		return removed, ErrOutOfBounds
+	}
+	c.Remove(removed.Key())
+	return removed, nil
+}
+
+func (c *comfyBiMap[K, V]) RemoveMany(keys []K) {
+	values := make([]V, 0, len(keys))
+	for _, k := range keys {
+		if v, ok := c.fwd.Get(k); ok {
+			values = append(values, v)
+		}
+	}
+	c.fwd.removeMany(keys)
+	c.bwd.removeMany(values)
+}
+
+func (c *comfyBiMap[K, V]) RemoveMatching(predicate Predicate[Pair[K, V]]) (count int) {
+	keys := []K(nil)
+	for _, pair := range c.fwd.s {
+		if predicate(pair.copy()) {
+			keys = append(keys, pair.Key())
+		}
+	}
+	c.RemoveMany(keys)
+	return len(keys)
+}
+
+func (c *comfyBiMap[K, V]) Reverse() {
+	c.fwd.Reverse()
+	c.bwd.Reverse()
+}
+
+func (c *comfyBiMap[K, V]) Set(k K, v V) {
+	_ = c.Put(k, v)
+}
+
+func (c *comfyBiMap[K, V]) SetMany(s []Pair[K, V]) {
+	c.putAll(s)
+}
+
+func (c *comfyBiMap[K, V]) Sort(compare PairComparator[K, V]) {
+	c.fwd.Sort(func(a, b Pair[K, V]) int {
+		return compare(a.copy(), b.copy())
+	})
+	c.syncBwd()
+}
+
+func (c *comfyBiMap[K, V]) Values() iter.Seq[Pair[K, V]] {
+	return func(yield func(Pair[K, V]) bool) {
+		for pair := range c.fwd.Values() {
+			if !yield(pair.copy()) {
+				break
+			}
+		}
+	}
+}
+
+func (c *comfyBiMap[K, V]) ValuesRev() iter.Seq[Pair[K, V]] {
+	return func(yield func(Pair[K, V]) bool) {
+		for pair := range c.fwd.ValuesRev() {
+			if !yield(pair.copy()) {
+				break
+			}
+		}
+	}
+}
+
+// Private:
+
+//nolint:unused
+func (c *comfyBiMap[K, V]) copy() baseInternal[Pair[K, V]] {
+	return &comfyBiMap[K, V]{
+		fwd:    c.fwd.copy().(*comfyMap[K, V]),
+		bwd:    c.bwd.copy().(*comfyMap[V, K]),
+		policy: c.policy,
+	}
+}
+
+// moveKeys moves the given keys, in the given order, to the front or to the back of the map.
+func (c *comfyBiMap[K, V]) moveKeys(keys []K, front bool) {
+	if len(keys) == 0 {
+		return
+	}
+
+	moved := make(map[K]struct{}, len(keys))
+	movedPairs := make([]Pair[K, V], 0, len(keys))
+	for _, k := range keys {
+		if _, ok := moved[k]; ok {
+			continue
+		}
+		// A key may have been evicted by a later pair of the same batch.
+		if v, ok := c.fwd.Get(k); ok {
+			moved[k] = struct{}{}
+			movedPairs = append(movedPairs, NewPair(k, v))
+		}
+	}
+
+	rest := make([]Pair[K, V], 0, len(c.fwd.s))
+	for _, pair := range c.fwd.s {
+		if _, ok := moved[pair.Key()]; !ok {
+			rest = append(rest, pair)
+		}
+	}
+
+	c.fwd.Clear()
+	if front {
+		c.fwd.SetMany(movedPairs)
+		c.fwd.SetMany(rest)
+	} else {
+		c.fwd.SetMany(rest)
+		c.fwd.SetMany(movedPairs)
+	}
+	c.syncBwd()
+}
+
+// putAll puts the given pairs and returns the keys of the pairs that were accepted.
+func (c *comfyBiMap[K, V]) putAll(pairs []Pair[K, V]) []K {
+	accepted := make([]K, 0, len(pairs))
+	for _, pair := range pairs {
+		if c.Put(pair.Key(), pair.Val()) == nil {
+			accepted = append(accepted, pair.Key())
+		}
+	}
+	return accepted
+}
+
+// syncBwd rebuilds the backward map, so that it follows the order of the forward map.
+func (c *comfyBiMap[K, V]) syncBwd() {
+	c.bwd.Clear()
+	for _, pair := range c.fwd.s {
+		c.bwd.set(NewPair(pair.Val(), pair.Key()))
+	}
+}
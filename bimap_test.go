@@ -0,0 +1,285 @@
+package coll
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func biMapKVs(m Map[string, int]) [][2]any {
+	kvs := [][2]any(nil)
+	for k, v := range m.KeyValues() {
+		kvs = append(kvs, [2]any{k, v})
+	}
+	return kvs
+}
+
+// biMapCheckLockstep verifies that both underlying maps hold the same pairs in the same order.
+func biMapCheckLockstep(t *testing.T, m BiMap[string, int]) {
+	t.Helper()
+	bm := m.(*comfyBiMap[string, int])
+	if len(bm.fwd.s) != len(bm.bwd.s) || len(bm.fwd.m) != len(bm.bwd.m) || len(bm.fwd.kp) != len(bm.bwd.kp) {
+		t.Fatalf("forward and backward maps have different sizes: %d, %d", len(bm.fwd.s), len(bm.bwd.s))
+	}
+	for i, pair := range bm.fwd.s {
+		inv := bm.bwd.s[i]
+		if inv.Key() != pair.Val() || inv.Val() != pair.Key() {
+			t.Fatalf("pair %d is (%v, %v) in forward map but (%v, %v) in backward map",
+				i, pair.Key(), pair.Val(), inv.Key(), inv.Val())
+		}
+		if bm.fwd.kp[pair.Key()] != i || bm.bwd.kp[pair.Val()] != i {
+			t.Fatalf("pair %d has invalid positions", i)
+		}
+	}
+}
+
+func TestNewBiMap(t *testing.T) {
+	m := NewBiMap[string, int](DuplicateValueReject)
+	if m == nil {
+		t.Fatal("NewBiMap[string, int]() returned nil")
+	}
+	if !m.IsEmpty() || m.Len() != 0 {
+		t.Error("NewBiMap[string, int]() is not empty")
+	}
+}
+
+func TestNewBiMapFrom(t *testing.T) {
+	m := NewBiMapFrom([]Pair[string, int]{
+		NewPair("a", 1),
+		NewPair("b", 2),
+		NewPair("c", 1),
+	}, DuplicateValueReject)
+
+	if got, want := biMapKVs(m), [][2]any{{"a", 1}, {"b", 2}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("NewBiMapFrom() = %v, want %v", got, want)
+	}
+	biMapCheckLockstep(t, m)
+}
+
+func Test_comfyBiMap_Put_reject(t *testing.T) {
+	m := NewBiMap[string, int](DuplicateValueReject)
+	cases := []struct {
+		name    string
+		k       string
+		v       int
+		wantErr error
+		want    [][2]any
+	}{
+		{name: "Put() new pair", k: "a", v: 1, want: [][2]any{{"a", 1}}},
+		{name: "Put() another pair", k: "b", v: 2, want: [][2]any{{"a", 1}, {"b", 2}}},
+		{name: "Put() same pair again", k: "a", v: 1, want: [][2]any{{"a", 1}, {"b", 2}}},
+		{name: "Put() duplicate value", k: "c", v: 1, wantErr: ErrDuplicateValue, want: [][2]any{{"a", 1}, {"b", 2}}},
+		{name: "Put() existing key to taken value", k: "a", v: 2, wantErr: ErrDuplicateValue, want: [][2]any{{"a", 1}, {"b", 2}}},
+		{name: "Put() existing key to new value", k: "a", v: 3, want: [][2]any{{"a", 3}, {"b", 2}}},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := m.Put(tt.k, tt.v); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Put() returned error %v, want %v", err, tt.wantErr)
+			}
+			if got := biMapKVs(m); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Put() resulted in: %v, but wanted %v", got, tt.want)
+			}
+			biMapCheckLockstep(t, m)
+		})
+	}
+	if m.HasValue(1) {
+		t.Error("HasValue(1) = true after the value was replaced")
+	}
+}
+
+func Test_comfyBiMap_Put_evict(t *testing.T) {
+	m := NewBiMapFrom([]Pair[string, int]{
+		NewPair("a", 1),
+		NewPair("b", 2),
+		NewPair("c", 3),
+	}, DuplicateValueEvict)
+
+	if err := m.Put("d", 1); err != nil {
+		t.Errorf("Put() returned error %v", err)
+	}
+	if err := m.Put("b", 3); err != nil {
+		t.Errorf("Put() returned error %v", err)
+	}
+	if got, want := biMapKVs(m), [][2]any{{"b", 3}, {"d", 1}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Put() resulted in: %v, but wanted %v", got, want)
+	}
+	biMapCheckLockstep(t, m)
+}
+
+func Test_comfyBiMap_GetKey(t *testing.T) {
+	m := NewBiMapFrom([]Pair[string, int]{NewPair("a", 1), NewPair("b", 2)}, DuplicateValueReject)
+
+	if k, ok := m.GetKey(2); !ok || k != "b" {
+		t.Errorf("GetKey(2) = %v, %v, want b, true", k, ok)
+	}
+	if k, ok := m.GetKey(3); ok || k != "" {
+		t.Errorf("GetKey(3) = %v, %v, want \"\", false", k, ok)
+	}
+	if !m.HasValue(1) || m.HasValue(3) {
+		t.Error("HasValue() returned wrong result")
+	}
+	if v, ok := m.Get("a"); !ok || v != 1 {
+		t.Errorf("Get(a) = %v, %v, want 1, true", v, ok)
+	}
+	if v := m.GetOrDefault("z", -1); v != -1 {
+		t.Errorf("GetOrDefault(z) = %v, want -1", v)
+	}
+}
+
+func Test_comfyBiMap_Inverse(t *testing.T) {
+	m := NewBiMapFrom([]Pair[string, int]{NewPair("a", 1), NewPair("b", 2)}, DuplicateValueReject)
+	inv := m.Inverse()
+
+	if k, ok := inv.Get(2); !ok || k != "b" {
+		t.Errorf("Inverse().Get(2) = %v, %v, want b, true", k, ok)
+	}
+
+	inv.Set(3, "c")
+	inv.Set(4, "a")
+	if v, ok := m.Get("c"); !ok || v != 3 {
+		t.Errorf("Get(c) after Inverse().Set() = %v, %v, want 3, true", v, ok)
+	}
+	if v, _ := m.Get("a"); v != 1 {
+		t.Errorf("Inverse().Set() bypassed the policy, Get(a) = %v", v)
+	}
+
+	m.Remove("b")
+	if inv.Has(2) {
+		t.Error("Inverse() is not a live view")
+	}
+	if back := inv.(BiMap[int, string]).Inverse(); !reflect.DeepEqual(back, m) {
+		t.Error("Inverse().Inverse() is not equal to the original map")
+	}
+	biMapCheckLockstep(t, m)
+}
+
+func Test_comfyBiMap_AppendPrepend(t *testing.T) {
+	m := NewBiMapFrom([]Pair[string, int]{
+		NewPair("a", 1),
+		NewPair("b", 2),
+		NewPair("c", 3),
+	}, DuplicateValueReject)
+
+	m.Append(NewPair("a", 10), NewPair("d", 2))
+	if got, want := biMapKVs(m), [][2]any{{"b", 2}, {"c", 3}, {"a", 10}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Append() resulted in: %v, but wanted %v", got, want)
+	}
+	biMapCheckLockstep(t, m)
+
+	m.Prepend(NewPair("e", 5), NewPair("c", 30))
+	if got, want := biMapKVs(m), [][2]any{{"e", 5}, {"c", 30}, {"b", 2}, {"a", 10}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Prepend() resulted in: %v, but wanted %v", got, want)
+	}
+	biMapCheckLockstep(t, m)
+
+	m.AppendColl(NewMapFrom([]Pair[string, int]{NewPair("f", 6)}))
+	if k, ok := m.GetKey(6); !ok || k != "f" {
+		t.Errorf("AppendColl() did not add the pair, GetKey(6) = %v, %v", k, ok)
+	}
+}
+
+func Test_comfyBiMap_Remove(t *testing.T) {
+	m := NewBiMapFrom([]Pair[string, int]{
+		NewPair("a", 1),
+		NewPair("b", 2),
+		NewPair("c", 3),
+		NewPair("d", 4),
+		NewPair("e", 5),
+	}, DuplicateValueReject)
+
+	m.Remove("a")
+	m.Remove("z")
+	m.RemoveMany([]string{"c", "y"})
+	removed, err := m.RemoveAt(1)
+	if err != nil || removed.Key() != "d" {
+		t.Errorf("RemoveAt(1) = %v, %v, want key d", removed, err)
+	}
+	if _, err = m.RemoveAt(5); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("RemoveAt(5) returned error %v, want ErrOutOfBounds", err)
+	}
+	if got, want := biMapKVs(m), [][2]any{{"b", 2}, {"e", 5}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Remove() resulted in: %v, but wanted %v", got, want)
+	}
+	if m.HasValue(1) || m.HasValue(3) || m.HasValue(4) {
+		t.Error("Remove() did not remove values")
+	}
+	biMapCheckLockstep(t, m)
+
+	count := m.RemoveMatching(func(p Pair[string, int]) bool { return p.Val() > 3 })
+	if count != 1 || m.Len() != 1 || m.HasValue(5) {
+		t.Errorf("RemoveMatching() returned %d, Len() = %d", count, m.Len())
+	}
+	biMapCheckLockstep(t, m)
+
+	m.Clear()
+	if !m.IsEmpty() || m.HasValue(2) {
+		t.Error("Clear() did not remove all pairs")
+	}
+}
+
+func Test_comfyBiMap_ApplySortReverse(t *testing.T) {
+	m := NewBiMapFrom([]Pair[string, int]{
+		NewPair("a", 3),
+		NewPair("b", 1),
+		NewPair("c", 2),
+	}, DuplicateValueReject)
+
+	m.Sort(func(a, b Pair[string, int]) int { return a.Val() - b.Val() })
+	if got := slices.Collect(m.Keys()); !reflect.DeepEqual(got, []string{"b", "c", "a"}) {
+		t.Errorf("Sort() resulted in: %v", got)
+	}
+	biMapCheckLockstep(t, m)
+
+	m.Reverse()
+	if got := slices.Collect(m.Keys()); !reflect.DeepEqual(got, []string{"a", "c", "b"}) {
+		t.Errorf("Reverse() resulted in: %v", got)
+	}
+	biMapCheckLockstep(t, m)
+
+	m.Apply(func(p Pair[string, int]) Pair[string, int] {
+		return NewPair(p.Key(), p.Val()%2)
+	})
+	if got, want := biMapKVs(m), [][2]any{{"a", 1}, {"c", 0}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Apply() resulted in: %v, but wanted %v", got, want)
+	}
+	biMapCheckLockstep(t, m)
+}
+
+func Test_comfyBiMap_Values_copies(t *testing.T) {
+	m := NewBiMapFrom([]Pair[string, int]{NewPair("a", 1), NewPair("b", 2)}, DuplicateValueReject)
+
+	for p := range m.Values() {
+		p.SetVal(2)
+	}
+	p, _ := m.At(0)
+	p.SetVal(2)
+	if got, want := biMapKVs(m), [][2]any{{"a", 1}, {"b", 2}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SetVal() on yielded pair modified the map: %v", got)
+	}
+
+	rev := [][2]any(nil)
+	for p := range m.ValuesRev() {
+		rev = append(rev, [2]any{p.Key(), p.Val()})
+	}
+	if want := [][2]any{{"b", 2}, {"a", 1}}; !reflect.DeepEqual(rev, want) {
+		t.Errorf("ValuesRev() = %v, want %v", rev, want)
+	}
+	if def := NewPair("x", 0); m.AtOrDefault(2, def) != def {
+		t.Error("AtOrDefault(2) did not return the default value")
+	}
+}
+
+func Test_comfyBiMap_copy(t *testing.T) {
+	c1 := NewBiMapFrom([]Pair[string, int]{NewPair("a", 1), NewPair("b", 2)}, DuplicateValueEvict)
+	c2 := Copy(c1)
+
+	if !reflect.DeepEqual(c1, c2) {
+		t.Errorf("Copy() = %v, want %v", c2, c1)
+	}
+	c1.Set("c", 1)
+	if k, _ := c2.GetKey(1); k != "a" {
+		t.Errorf("copy() did not create a deep copy, GetKey(1) = %v", k)
+	}
+}
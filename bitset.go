@@ -0,0 +1,288 @@
+package coll
+
+import (
+	"iter"
+	"math/bits"
+)
+
+const bitSetWordSize = 64
+
+// BitSet is a set of non-negative integers stored as a bit vector.
+// It uses one bit per value up to the largest stored value, which makes it much more compact
+// than a Set or CmpSequence for dense sets of small integers.
+// Values are always kept in ascending order.
+type BitSet interface {
+	Ordered[int]
+	Mutable[int]
+
+	// Add adds the given values to the set.
+	// Returns ErrOutOfBounds and adds nothing if any of the values is negative.
+	Add(v ...int) error
+
+	// Cardinality returns the number of values in the set.
+	// It is equivalent to Len.
+	Cardinality() int
+
+	// Contains returns true if the set contains the given value.
+	Contains(v int) bool
+
+	// Difference returns a new set with the values of this set that are not present in the other set.
+	Difference(other BitSet) BitSet
+
+	// Intersect returns a new set with the values of this set that are also present in the other set.
+	Intersect(other BitSet) BitSet
+
+	// NextSet returns the smallest value in the set that is greater than or equal to i.
+	// Returns false if there is no such value.
+	NextSet(i int) (int, bool)
+
+	// Remove removes the given values from the set.
+	// Returns the number of removed items.
+	Remove(v ...int) (count int)
+
+	// Union returns a new set with the values of both sets.
+	Union(other BitSet) BitSet
+}
+
+type comfyBitSet struct {
+	words []uint64
+	size  int
+}
+
+// NewBitSet creates a new BitSet instance.
+func NewBitSet() BitSet {
+	return &comfyBitSet{}
+}
+
+// NewBitSetFrom creates a new BitSet instance from a slice.
+// It panics if any of the values is negative.
+func NewBitSetFrom(s []int) BitSet {
+	bs := NewBitSet()
+	if err := bs.Add(s...); err != nil {
+		panic("NewBitSetFrom() requires non-negative values")
+	}
+	return bs
+}
+
+func (c *comfyBitSet) Add(v ...int) error {
+	for _, v := range v {
+		if v < 0 {
+			return ErrOutOfBounds
+		}
+	}
+	for _, v := range v {
+		c.add(v)
+	}
+	return nil
+}
+
+// Apply applies the given function to each value of the set.
+// Mapped values that are negative are dropped.
+func (c *comfyBitSet) Apply(f Mapper[int]) {
+	old := c.copy().(*comfyBitSet)
+	c.Clear()
+	for v := range old.Values() {
+		if mapped := f(v); mapped >= 0 {
+			c.add(mapped)
+		}
+	}
+}
+
+func (c *comfyBitSet) Cardinality() int {
+	return c.size
+}
+
+func (c *comfyBitSet) Clear() {
+	c.words = nil
+	c.size = 0
+}
+
+func (c *comfyBitSet) Contains(v int) bool {
+	w := v / bitSetWordSize
+	if v < 0 || w >= len(c.words) {
+		return false
+	}
+	return c.words[w]&(1<<(v%bitSetWordSize)) != 0
+}
+
+func (c *comfyBitSet) Difference(other BitSet) BitSet {
+	o := toComfyBitSet(other)
+	return c.combine(func(i int, w uint64) uint64 {
+		if i < len(o.words) {
+			return w &^ o.words[i]
+		}
+		return w
+	}, len(c.words))
+}
+
+func (c *comfyBitSet) Intersect(other BitSet) BitSet {
+	o := toComfyBitSet(other)
+	return c.combine(func(i int, w uint64) uint64 {
+		return w & o.words[i]
+	}, min(len(c.words), len(o.words)))
+}
+
+func (c *comfyBitSet) IsEmpty() bool {
+	return c.size == 0
+}
+
+func (c *comfyBitSet) Len() int {
+	return c.size
+}
+
+func (c *comfyBitSet) NextSet(i int) (int, bool) {
+	i = max(i, 0)
+	w := i / bitSetWordSize
+	if w >= len(c.words) {
+		return 0, false
+	}
+	// Skip the bits below i in the first word.
+	word := c.words[w] >> (i % bitSetWordSize) << (i % bitSetWordSize)
+	for {
+		if word != 0 {
+			return w*bitSetWordSize + bits.TrailingZeros64(word), true
+		}
+		w++
+		if w >= len(c.words) {
+			return 0, false
+		}
+		word = c.words[w]
+	}
+}
+
+func (c *comfyBitSet) Remove(v ...int) (count int) {
+	for _, v := range v {
+		if c.Contains(v) {
+			c.words[v/bitSetWordSize] &^= 1 << (v % bitSetWordSize)
+			c.size--
+			count++
+		}
+	}
+	if count > 0 {
+		c.trim()
+	}
+	return count
+}
+
+func (c *comfyBitSet) RemoveMatching(predicate Predicate[int]) (count int) {
+	toRemove := []int(nil)
+	for v := range c.Values() {
+		if predicate(v) {
+			toRemove = append(toRemove, v)
+		}
+	}
+	return c.Remove(toRemove...)
+}
+
+func (c *comfyBitSet) Union(other BitSet) BitSet {
+	o := toComfyBitSet(other)
+	long, short := c, o
+	if len(o.words) > len(c.words) {
+		long, short = o, c
+	}
+	return long.combine(func(i int, w uint64) uint64 {
+		if i < len(short.words) {
+			return w | short.words[i]
+		}
+		return w
+	}, len(long.words))
+}
+
+func (c *comfyBitSet) Values() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i, word := range c.words {
+			for word != 0 {
+				b := bits.TrailingZeros64(word)
+				if !yield(i*bitSetWordSize + b) {
+					return
+				}
+				word &^= 1 << b
+			}
+		}
+	}
+}
+
+func (c *comfyBitSet) ValuesRev() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := len(c.words) - 1; i >= 0; i-- {
+			word := c.words[i]
+			for word != 0 {
+				b := bitSetWordSize - 1 - bits.LeadingZeros64(word)
+				if !yield(i*bitSetWordSize + b) {
+					return
+				}
+				word &^= 1 << b
+			}
+		}
+	}
+}
+
+// Private:
+
+func (c *comfyBitSet) add(v int) {
+	w := v / bitSetWordSize
+	if w >= len(c.words) {
+		c.words = append(c.words, make([]uint64, w+1-len(c.words))...)
+	}
+	mask := uint64(1) << (v % bitSetWordSize)
+	if c.words[w]&mask == 0 {
+		c.words[w] |= mask
+		c.size++
+	}
+}
+
+// combine creates a new set from the first n words, each computed by the given function.
+func (c *comfyBitSet) combine(word func(i int, w uint64) uint64, n int) BitSet {
+	combined := &comfyBitSet{}
+	if n > 0 {
+		combined.words = make([]uint64, n)
+	}
+	for i := 0; i < n; i++ {
+		combined.words[i] = word(i, c.words[i])
+		combined.size += bits.OnesCount64(combined.words[i])
+	}
+	combined.trim()
+	return combined
+}
+
+//nolint:unused
+func (c *comfyBitSet) copy() baseInternal[int] {
+	newBs := &comfyBitSet{size: c.size}
+	if c.words != nil {
+		newBs.words = make([]uint64, len(c.words))
+		copy(newBs.words, c.words)
+	}
+	return newBs
+}
+
+// toComfyBitSet returns the given set as a comfyBitSet, copying its values if it is another implementation.
+func toComfyBitSet(s BitSet) *comfyBitSet {
+	if bs, ok := s.(*comfyBitSet); ok {
+		return bs
+	}
+	bs := &comfyBitSet{}
+	for v := range s.Values() {
+		if v >= 0 {
+			bs.add(v)
+		}
+	}
+	return bs
+}
+
+// trim drops trailing empty words. Once at most half of the allocated words are in use,
+// they are moved to a smaller slice, so that memory is released when the largest values are removed.
+func (c *comfyBitSet) trim() {
+	n := len(c.words)
+	for n > 0 && c.words[n-1] == 0 {
+		n--
+	}
+	if n == 0 {
+		c.words = nil
+		return
+	}
+	if n <= cap(c.words)/2 {
+		c.words = append([]uint64(nil), c.words[:n]...)
+		return
+	}
+	c.words = c.words[:n]
+}
@@ -0,0 +1,302 @@
+package coll
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+type comfyBitSetIntBuilder[C any] struct {
+}
+
+func (lcb *comfyBitSetIntBuilder[C]) Empty() C {
+	return any(lcb.make([]int(nil))).(C)
+}
+
+func (lcb *comfyBitSetIntBuilder[C]) One() C {
+	return any(lcb.make([]int{111})).(C)
+}
+
+func (lcb *comfyBitSetIntBuilder[C]) Two() C {
+	return any(lcb.make([]int{123, 234})).(C)
+}
+
+func (lcb *comfyBitSetIntBuilder[C]) Three() C {
+	return any(lcb.make([]int{111, 222, 333})).(C)
+}
+
+func (lcb *comfyBitSetIntBuilder[C]) ThreeRev() C {
+	return any(lcb.make([]int{333, 222, 111})).(C)
+}
+
+func (lcb *comfyBitSetIntBuilder[C]) SixWithDuplicates() C {
+	return any(lcb.make([]int{111, 222, 333, 111, 222, 333})).(C)
+}
+
+func (lcb *comfyBitSetIntBuilder[C]) FromValues(values []any) C {
+	c := lcb.make([]int{})
+	for _, v := range values {
+		c.add(v.(int))
+	}
+	return any(c).(C)
+}
+
+func (lcb *comfyBitSetIntBuilder[C]) make(items []int) *comfyBitSet {
+	return NewBitSetFrom(items).(*comfyBitSet)
+}
+
+func (lcb *comfyBitSetIntBuilder[C]) extractRawValues(c C) any {
+	return lcb.extractUnderlyingSlice(c)
+}
+
+func (lcb *comfyBitSetIntBuilder[C]) extractUnderlyingSlice(c C) any {
+	return slices.Collect((any(c)).(*comfyBitSet).Values())
+}
+
+func (lcb *comfyBitSetIntBuilder[C]) extractUnderlyingMap(_ C) any {
+	return nil
+}
+
+func (lcb *comfyBitSetIntBuilder[C]) extractUnderlyingKp(_ C) any {
+	return nil
+}
+
+func (lcb *comfyBitSetIntBuilder[C]) extractUnderlyingValsCount(_ C) any {
+	return nil
+}
+
+func TestNewBitSet(t *testing.T) {
+	bs := NewBitSet()
+	if bs == nil {
+		t.Error("NewBitSet() returned nil")
+	}
+	if !reflect.DeepEqual(bs, &comfyBitSet{}) {
+		t.Error("NewBitSet() did not return an empty comfyBitSet")
+	}
+}
+
+func TestNewBitSetFrom(t *testing.T) {
+	bs := NewBitSetFrom([]int{65, 1, 65, 0})
+	want := &comfyBitSet{words: []uint64{0b11, 0b10}, size: 3}
+	if !reflect.DeepEqual(bs, want) {
+		t.Errorf("NewBitSetFrom() = %v, want %v", bs, want)
+	}
+
+	t.Run("NewBitSetFrom() with negative value", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("NewBitSetFrom() did not panic")
+			}
+		}()
+		NewBitSetFrom([]int{1, -1})
+	})
+}
+
+func Test_comfyBitSet_Add(t *testing.T) {
+	cases := []struct {
+		name    string
+		coll    BitSet
+		values  []int
+		want    []int
+		wantErr error
+	}{
+		{
+			name:   "Add() on empty set",
+			coll:   NewBitSet(),
+			values: []int{200, 0, 63, 64},
+			want:   []int{0, 63, 64, 200},
+		},
+		{
+			name:   "Add() existing values",
+			coll:   NewBitSetFrom([]int{1, 2}),
+			values: []int{2, 2, 3},
+			want:   []int{1, 2, 3},
+		},
+		{
+			name:    "Add() with negative value adds nothing",
+			coll:    NewBitSetFrom([]int{1}),
+			values:  []int{5, -1},
+			want:    []int{1},
+			wantErr: ErrOutOfBounds,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.coll.Add(tt.values...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Add() returned error %v, want %v", err, tt.wantErr)
+			}
+			if got := slices.Collect(tt.coll.Values()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Add() resulted in: %v, but wanted %v", got, tt.want)
+			}
+			if tt.coll.Cardinality() != len(tt.want) {
+				t.Errorf("Cardinality() = %d, want %d", tt.coll.Cardinality(), len(tt.want))
+			}
+		})
+	}
+}
+
+func Test_comfyBitSet_Apply(t *testing.T) {
+	coll := NewBitSetFrom([]int{1, 2, 3, 4})
+	coll.Apply(func(v int) int { return v/2 - 1 })
+	if got := slices.Collect(coll.Values()); !reflect.DeepEqual(got, []int{0, 1}) {
+		t.Errorf("Apply() resulted in: %v, but wanted %v", got, []int{0, 1})
+	}
+	if coll.Len() != 2 {
+		t.Errorf("Len() after Apply() = %d, want 2", coll.Len())
+	}
+}
+
+func Test_comfyBitSet_Clear(t *testing.T) {
+	testClear(t, &comfyBitSetIntBuilder[mutableInternal[int]]{})
+}
+
+func Test_comfyBitSet_Contains(t *testing.T) {
+	coll := NewBitSetFrom([]int{0, 64, 1000})
+	cases := map[int]bool{0: true, 64: true, 1000: true, 1: false, 63: false, 1001: false, 100000: false, -1: false}
+	for v, want := range cases {
+		if got := coll.Contains(v); got != want {
+			t.Errorf("Contains(%d) = %v, want %v", v, got, want)
+		}
+	}
+}
+
+func Test_comfyBitSet_IsEmpty(t *testing.T) {
+	testIsEmpty(t, &comfyBitSetIntBuilder[baseInternal[int]]{})
+}
+
+func Test_comfyBitSet_Len(t *testing.T) {
+	testLen(t, &comfyBitSetIntBuilder[baseInternal[int]]{})
+}
+
+func Test_comfyBitSet_NextSet(t *testing.T) {
+	coll := NewBitSetFrom([]int{3, 64, 130})
+	cases := []struct {
+		i      int
+		want   int
+		wantOk bool
+	}{
+		{i: -5, want: 3, wantOk: true},
+		{i: 3, want: 3, wantOk: true},
+		{i: 4, want: 64, wantOk: true},
+		{i: 65, want: 130, wantOk: true},
+		{i: 131, wantOk: false},
+		{i: 1000, wantOk: false},
+	}
+	for _, tt := range cases {
+		if got, ok := coll.NextSet(tt.i); got != tt.want || ok != tt.wantOk {
+			t.Errorf("NextSet(%d) = %d, %v, want %d, %v", tt.i, got, ok, tt.want, tt.wantOk)
+		}
+	}
+	if _, ok := NewBitSet().NextSet(0); ok {
+		t.Error("NextSet(0) on empty set returned true")
+	}
+}
+
+func Test_comfyBitSet_Remove(t *testing.T) {
+	coll := NewBitSetFrom([]int{1, 2, 200}).(*comfyBitSet)
+	if count := coll.Remove(2, 200, 5, 2, -1); count != 2 {
+		t.Errorf("Remove() returned wrong count: %v, but wanted = %v", count, 2)
+	}
+	want := &comfyBitSet{words: []uint64{0b10}, size: 1}
+	if !reflect.DeepEqual(coll, want) {
+		t.Errorf("Remove() resulted in: %v, but wanted %v", coll, want)
+	}
+	if cap(coll.words) != 1 {
+		t.Errorf("Remove() kept %d allocated words, want 1", cap(coll.words))
+	}
+	coll.Remove(1)
+	if !reflect.DeepEqual(coll, &comfyBitSet{}) {
+		t.Errorf("Remove() of all values did not release words: %v", coll)
+	}
+}
+
+func Test_comfyBitSet_RemoveMatching(t *testing.T) {
+	testRemoveMatching(t, &comfyBitSetIntBuilder[mutableInternal[int]]{})
+}
+
+func Test_comfyBitSet_setOperations(t *testing.T) {
+	a := NewBitSetFrom([]int{1, 2, 3, 4, 300})
+	b := NewBitSetFrom([]int{6, 4, 5, 3})
+
+	cases := []struct {
+		name string
+		got  BitSet
+		want []int
+	}{
+		{name: "Union()", got: a.Union(b), want: []int{1, 2, 3, 4, 5, 6, 300}},
+		{name: "Union() reversed", got: b.Union(a), want: []int{1, 2, 3, 4, 5, 6, 300}},
+		{name: "Intersect()", got: a.Intersect(b), want: []int{3, 4}},
+		{name: "Difference()", got: a.Difference(b), want: []int{1, 2, 300}},
+		{name: "Difference() reversed", got: b.Difference(a), want: []int{5, 6}},
+		{name: "Union() with empty", got: a.Union(NewBitSet()), want: []int{1, 2, 3, 4, 300}},
+		{name: "Intersect() with empty", got: a.Intersect(NewBitSet()), want: []int(nil)},
+		{name: "Difference() with itself", got: a.Difference(a), want: []int(nil)},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := slices.Collect(tt.got.Values()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+			}
+			if tt.got.Cardinality() != len(tt.want) {
+				t.Errorf("%s Cardinality() = %d, want %d", tt.name, tt.got.Cardinality(), len(tt.want))
+			}
+		})
+	}
+
+	t.Run("other implementations", func(t *testing.T) {
+		wrapped := struct{ BitSet }{b}
+		if got := slices.Collect(a.Union(wrapped).Values()); !reflect.DeepEqual(got, []int{1, 2, 3, 4, 5, 6, 300}) {
+			t.Errorf("Union() = %v", got)
+		}
+		if got := slices.Collect(a.Intersect(wrapped).Values()); !reflect.DeepEqual(got, []int{3, 4}) {
+			t.Errorf("Intersect() = %v", got)
+		}
+		if got := slices.Collect(a.Difference(wrapped).Values()); !reflect.DeepEqual(got, []int{1, 2, 300}) {
+			t.Errorf("Difference() = %v", got)
+		}
+	})
+
+	t.Run("results are trimmed", func(t *testing.T) {
+		if got := a.Intersect(b); !reflect.DeepEqual(got, &comfyBitSet{words: []uint64{0b11000}, size: 2}) {
+			t.Errorf("Intersect() = %v", got)
+		}
+		if got := a.Difference(a); !reflect.DeepEqual(got, &comfyBitSet{}) {
+			t.Errorf("Difference() = %v", got)
+		}
+	})
+
+	t.Run("operands are not modified", func(t *testing.T) {
+		if got := slices.Collect(a.Values()); !reflect.DeepEqual(got, []int{1, 2, 3, 4, 300}) {
+			t.Errorf("left operand was modified: %v", got)
+		}
+		if got := slices.Collect(b.Values()); !reflect.DeepEqual(got, []int{3, 4, 5, 6}) {
+			t.Errorf("right operand was modified: %v", got)
+		}
+	})
+}
+
+func Test_comfyBitSet_Values(t *testing.T) {
+	testValues(t, &comfyBitSetIntBuilder[baseInternal[int]]{})
+	testValuesBreak(t, &comfyBitSetIntBuilder[baseInternal[int]]{})
+}
+
+func Test_comfyBitSet_ValuesRev(t *testing.T) {
+	testValuesRev(t, &comfyBitSetIntBuilder[orderedInternal[int]]{})
+	testValuesRevBreak(t, &comfyBitSetIntBuilder[orderedInternal[int]]{})
+}
+
+func Test_comfyBitSet_copy(t *testing.T) {
+	testCopy(t, &comfyBitSetIntBuilder[baseInternal[int]]{})
+}
+
+func Test_comfyBitSet_copy_pointer(t *testing.T) {
+	c1 := NewBitSetFrom([]int{123, 234, 345})
+	c2 := Copy(c1)
+
+	_ = c1.Add(999)
+	if c2.Contains(999) {
+		t.Error("copy() did not create a deep copy")
+	}
+}
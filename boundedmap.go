@@ -0,0 +1,296 @@
+package coll
+
+import (
+	"iter"
+	"slices"
+)
+
+// CacheStats holds usage statistics of a capacity-bounded collection.
+type CacheStats struct {
+	// Hits is the number of lookups that found the key.
+	Hits int
+
+	// Misses is the number of lookups that did not find the key.
+	Misses int
+
+	// Evictions is the number of keys removed to make room for new ones.
+	Evictions int
+}
+
+// BoundedMap is a Map that holds at most Cap() keys.
+// When a new key is added to a full map, the EvictionPolicy given at construction selects the key to evict.
+//
+// Get and GetOrDefault count as a use of the key and are recorded in Stats, while Has, At and iteration are not.
+// Append, Prepend and SetMany are equivalent: pairs are set one by one in the given order.
+//
+// Pairs are kept in a linked list indexed by key, so that Get, Set and Remove are O(1),
+// while At and RemoveAt walk the list and are O(n).
+type BoundedMap[K comparable, V any] interface {
+	Map[K, V]
+
+	// Cap returns the maximum number of keys the map can hold.
+	Cap() int
+
+	// Stats returns the hit, miss and eviction counters.
+	Stats() CacheStats
+}
+
+type comfyBoundedMap[K comparable, V any] struct {
+	l        *comfyList[Pair[K, V]]
+	m        map[K]*comfyListNode[Pair[K, V]]
+	policy   EvictionPolicy[K]
+	capacity int
+	stats    CacheStats
+}
+
+// NewBoundedMap creates a new BoundedMap instance with the given capacity and eviction policy.
+// The policy must be a new instance that is not used by any other collection.
+// If it implements CapacityAware, it is told the capacity.
+// It panics if capacity is less than 1.
+func NewBoundedMap[K comparable, V any](capacity int, policy EvictionPolicy[K]) BoundedMap[K, V] {
+	if capacity < 1 {
+		panic("NewBoundedMap() requires a capacity of at least 1")
+	}
+	if ca, ok := policy.(CapacityAware); ok {
+		ca.SetCapacity(capacity)
+	}
+	return &comfyBoundedMap[K, V]{
+		l:        &comfyList[Pair[K, V]]{},
+		m:        make(map[K]*comfyListNode[Pair[K, V]]),
+		policy:   policy,
+		capacity: capacity,
+	}
+}
+
+func (c *comfyBoundedMap[K, V]) Append(p ...Pair[K, V]) {
+	c.SetMany(p)
+}
+
+func (c *comfyBoundedMap[K, V]) AppendColl(coll Ordered[Pair[K, V]]) {
+	c.SetMany(slices.Collect(coll.Values()))
+}
+
+// Apply replaces every pair with the mapped one in place, keeping the state of the eviction policy.
+// A pair mapped to a new key is tracked by the policy as newly inserted.
+// If mapped pairs share a key, the one that is mapped last is kept.
+func (c *comfyBoundedMap[K, V]) Apply(f Mapper[Pair[K, V]]) {
+	nodes := make([]*comfyListNode[Pair[K, V]], 0, c.l.Len())
+	mapped := make([]Pair[K, V], 0, c.l.Len())
+	for n := c.l.head; n != nil; n = n.next {
+		nodes = append(nodes, n)
+		mapped = append(mapped, f(n.v))
+	}
+
+	// Forget the keys that changed first, so that a mapped key may take over a key that another pair gave up.
+	for i, n := range nodes {
+		if k := n.v.Key(); mapped[i].Key() != k {
+			delete(c.m, k)
+			c.policy.Remove(k)
+		}
+	}
+	for i, n := range nodes {
+		k := mapped[i].Key()
+		existing, ok := c.m[k]
+		switch {
+		case ok && existing != n:
+			existing.v = mapped[i]
+			c.l.unlink(n)
+		case ok:
+			n.v = mapped[i]
+		default:
+			n.v = mapped[i]
+			c.m[k] = n
+			c.policy.Insert(k)
+		}
+	}
+}
+
+func (c *comfyBoundedMap[K, V]) At(i int) (p Pair[K, V], found bool) {
+	return c.l.At(i)
+}
+
+func (c *comfyBoundedMap[K, V]) AtOrDefault(i int, defaultValue Pair[K, V]) Pair[K, V] {
+	return c.l.AtOrDefault(i, defaultValue)
+}
+
+func (c *comfyBoundedMap[K, V]) Cap() int {
+	return c.capacity
+}
+
+func (c *comfyBoundedMap[K, V]) Clear() {
+	c.l.Clear()
+	c.m = make(map[K]*comfyListNode[Pair[K, V]])
+	c.policy.Clear()
+}
+
+func (c *comfyBoundedMap[K, V]) Get(k K) (V, bool) {
+	n, ok := c.m[k]
+	if !ok {
+		c.stats.Misses++
+		var v V
+		return v, false
+	}
+	c.stats.Hits++
+	c.policy.Access(k)
+	return n.v.Val(), true
+}
+
+func (c *comfyBoundedMap[K, V]) GetOrDefault(k K, defaultValue V) V {
+	if v, ok := c.Get(k); ok {
+		return v
+	}
+	return defaultValue
+}
+
+func (c *comfyBoundedMap[K, V]) Has(k K) bool {
+	_, ok := c.m[k]
+	return ok
+}
+
+func (c *comfyBoundedMap[K, V]) IsEmpty() bool {
+	return c.l.IsEmpty()
+}
+
+func (c *comfyBoundedMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for pair := range c.l.Values() {
+			if !yield(pair.Key()) {
+				break
+			}
+		}
+	}
+}
+
+func (c *comfyBoundedMap[K, V]) KeyValues() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for pair := range c.l.Values() {
+			if !yield(pair.Key(), pair.Val()) {
+				break
+			}
+		}
+	}
+}
+
+func (c *comfyBoundedMap[K, V]) Len() int {
+	return c.l.Len()
+}
+
+func (c *comfyBoundedMap[K, V]) Prepend(p ...Pair[K, V]) {
+	c.SetMany(p)
+}
+
+func (c *comfyBoundedMap[K, V]) Remove(k K) {
+	n, ok := c.m[k]
+	if !ok {
+		return
+	}
+	c.unlink(n)
+	c.policy.Remove(k)
+}
+
+func (c *comfyBoundedMap[K, V]) RemoveAt(idx int) (removed Pair[K, V], err error) {
+	n := c.l.nodeAt(idx)
+	if n == nil {
+		return removed, ErrOutOfBounds
+	}
+	c.unlink(n)
+	c.policy.Remove(n.v.Key())
+	return n.v, nil
+}
+
+func (c *comfyBoundedMap[K, V]) RemoveMany(keys []K) {
+	for _, k := range keys {
+		c.Remove(k)
+	}
+}
+
+func (c *comfyBoundedMap[K, V]) RemoveMatching(predicate Predicate[Pair[K, V]]) (count int) {
+	for n := c.l.head; n != nil; {
+		next := n.next
+		if predicate(n.v) {
+			c.unlink(n)
+			c.policy.Remove(n.v.Key())
+			count++
+		}
+		n = next
+	}
+	return count
+}
+
+func (c *comfyBoundedMap[K, V]) Reverse() {
+	c.l.Reverse()
+}
+
+func (c *comfyBoundedMap[K, V]) Set(k K, v V) {
+	c.set(NewPair(k, v))
+}
+
+func (c *comfyBoundedMap[K, V]) SetMany(s []Pair[K, V]) {
+	for _, pair := range s {
+		c.set(pair)
+	}
+}
+
+func (c *comfyBoundedMap[K, V]) Sort(compare PairComparator[K, V]) {
+	c.l.Sort(compare)
+	for n := c.l.head; n != nil; n = n.next {
+		c.m[n.v.Key()] = n
+	}
+}
+
+func (c *comfyBoundedMap[K, V]) Stats() CacheStats {
+	return c.stats
+}
+
+func (c *comfyBoundedMap[K, V]) Values() iter.Seq[Pair[K, V]] {
+	return c.l.Values()
+}
+
+func (c *comfyBoundedMap[K, V]) ValuesRev() iter.Seq[Pair[K, V]] {
+	return c.l.ValuesRev()
+}
+
+// Private:
+
+//nolint:unused
+func (c *comfyBoundedMap[K, V]) copy() baseInternal[Pair[K, V]] {
+	newBm := &comfyBoundedMap[K, V]{
+		l:        &comfyList[Pair[K, V]]{},
+		m:        make(map[K]*comfyListNode[Pair[K, V]], len(c.m)),
+		policy:   c.policy.Clone(),
+		capacity: c.capacity,
+		stats:    c.stats,
+	}
+	for pair := range c.l.Values() {
+		newBm.m[pair.Key()] = newBm.l.pushBack(pair.copy())
+	}
+	return newBm
+}
+
+func (c *comfyBoundedMap[K, V]) set(pair Pair[K, V]) {
+	k := pair.Key()
+	if n, ok := c.m[k]; ok {
+		n.v = pair
+		c.policy.Access(k)
+		return
+	}
+
+	for c.l.Len() >= c.capacity {
+		victim, ok := c.policy.Victim(k)
+		if !ok {
+			break
+		}
+		if n, ok := c.m[victim]; ok {
+			c.unlink(n)
+		}
+		c.stats.Evictions++
+	}
+	c.m[k] = c.l.pushBack(pair)
+	c.policy.Insert(k)
+}
+
+// unlink removes the node from the list and the key index without notifying the policy.
+func (c *comfyBoundedMap[K, V]) unlink(n *comfyListNode[Pair[K, V]]) {
+	c.l.unlink(n)
+	delete(c.m, n.v.Key())
+}
@@ -0,0 +1,237 @@
+package coll
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestNewBoundedMap(t *testing.T) {
+	m := NewBoundedMap[string, int](2, NewARCPolicy[string]())
+	if m == nil {
+		t.Fatal("NewBoundedMap[string, int]() returned nil")
+	}
+	if m.Cap() != 2 || !m.IsEmpty() {
+		t.Errorf("NewBoundedMap[string, int]() returned unexpected state: cap %d, len %d", m.Cap(), m.Len())
+	}
+	if p := m.(*comfyBoundedMap[string, int]).policy.(*arcPolicy[string]); p.capacity != 2 {
+		t.Errorf("NewBoundedMap() did not pass the capacity to the policy, got %d", p.capacity)
+	}
+
+	t.Run("NewBoundedMap() with zero capacity panics", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("NewBoundedMap(0) did not panic")
+			}
+		}()
+		NewBoundedMap[string, int](0, NewLRUPolicy[string]())
+	})
+}
+
+func Test_comfyBoundedMap_policies(t *testing.T) {
+	cases := []struct {
+		name     string
+		policy   EvictionPolicy[string]
+		wantKeys []string
+	}{
+		{name: "LRU", policy: NewLRUPolicy[string](), wantKeys: []string{"a", "c", "d"}},
+		{name: "LFU", policy: NewLFUPolicy[string](), wantKeys: []string{"a", "b", "d"}},
+		{name: "ARC", policy: NewARCPolicy[string](), wantKeys: []string{"a", "c", "d"}},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewBoundedMap[string, int](3, tt.policy)
+			m.Set("a", 1)
+			m.Set("b", 2)
+			m.Set("c", 3)
+			m.Get("a")
+			m.Get("b")
+			m.Get("b")
+			m.Get("a")
+			m.Get("c")
+			m.Get("x")
+			m.Set("d", 4)
+
+			got := slices.Sorted(m.Keys())
+			if !reflect.DeepEqual(got, tt.wantKeys) {
+				t.Errorf("Keys() = %v, want %v", got, tt.wantKeys)
+			}
+			if want := (CacheStats{Hits: 5, Misses: 1, Evictions: 1}); m.Stats() != want {
+				t.Errorf("Stats() = %+v, want %+v", m.Stats(), want)
+			}
+		})
+	}
+}
+
+func Test_comfyBoundedMap_Set(t *testing.T) {
+	m := NewBoundedMap[string, int](2, NewLRUPolicy[string]())
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("a", 10)
+	m.SetMany([]Pair[string, int]{NewPair("c", 3)})
+
+	if !m.Has("a") || m.Has("b") || m.Len() != 2 {
+		t.Errorf("Set() on existing key did not count as a use: %v", slices.Collect(m.Keys()))
+	}
+	if v := m.GetOrDefault("a", -1); v != 10 {
+		t.Errorf("GetOrDefault(a) = %v, want 10", v)
+	}
+	if v := m.GetOrDefault("b", -1); v != -1 {
+		t.Errorf("GetOrDefault(b) = %v, want -1", v)
+	}
+
+	m.Append(NewPair("d", 4))
+	m.Prepend(NewPair("e", 5))
+	m.AppendColl(NewMapFrom([]Pair[string, int]{NewPair("f", 6)}))
+	if got := slices.Collect(m.Keys()); !reflect.DeepEqual(got, []string{"e", "f"}) {
+		t.Errorf("Keys() = %v, want %v", got, []string{"e", "f"})
+	}
+	if m.Stats().Evictions != 4 {
+		t.Errorf("Stats().Evictions = %d, want 4", m.Stats().Evictions)
+	}
+}
+
+func Test_comfyBoundedMap_Remove(t *testing.T) {
+	m := NewBoundedMap[int, int](5, NewLRUPolicy[int]())
+	for i := range 5 {
+		m.Set(i, i*10)
+	}
+
+	m.Remove(0)
+	m.Remove(42)
+	m.RemoveMany([]int{1, 42})
+	removed, err := m.RemoveAt(0)
+	if err != nil || removed.Key() != 2 {
+		t.Errorf("RemoveAt(0) = %v, %v, want key 2", removed, err)
+	}
+	if _, err = m.RemoveAt(5); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("RemoveAt(5) returned error %v, want ErrOutOfBounds", err)
+	}
+	if count := m.RemoveMatching(func(p Pair[int, int]) bool { return p.Val() == 30 }); count != 1 {
+		t.Errorf("RemoveMatching() = %d, want 1", count)
+	}
+	if got := slices.Collect(m.Keys()); !reflect.DeepEqual(got, []int{4}) {
+		t.Errorf("Keys() after removals = %v, want %v", got, []int{4})
+	}
+
+	// Removed keys must not be chosen as victims.
+	for i := 10; i < 14; i++ {
+		m.Set(i, i)
+	}
+	m.Set(20, 20)
+	if m.Len() != 5 || m.Stats().Evictions != 1 || m.Has(4) {
+		t.Errorf("Len() = %d, Evictions = %d, Has(4) = %v, want 5, 1, false", m.Len(), m.Stats().Evictions, m.Has(4))
+	}
+
+	m.Clear()
+	if !m.IsEmpty() {
+		t.Error("Clear() did not remove all pairs")
+	}
+	if _, ok := m.(*comfyBoundedMap[int, int]).policy.Victim(0); ok {
+		t.Error("Clear() did not clear the policy")
+	}
+}
+
+func Test_comfyBoundedMap_ordering(t *testing.T) {
+	m := NewBoundedMap[int, int](3, NewLFUPolicy[int]())
+	m.Set(1, 30)
+	m.Set(2, 10)
+	m.Set(3, 20)
+
+	m.Sort(func(a, b Pair[int, int]) int { return a.Val() - b.Val() })
+	if got := slices.Collect(m.Keys()); !reflect.DeepEqual(got, []int{2, 3, 1}) {
+		t.Errorf("Sort() resulted in: %v", got)
+	}
+	m.Reverse()
+	if got := slices.Collect(m.Keys()); !reflect.DeepEqual(got, []int{1, 3, 2}) {
+		t.Errorf("Reverse() resulted in: %v", got)
+	}
+	if p, ok := m.At(0); !ok || p.Key() != 1 {
+		t.Errorf("At(0) = %v, %v, want key 1", p, ok)
+	}
+	if def := NewPair(0, 0); m.AtOrDefault(3, def) != def {
+		t.Error("AtOrDefault(3) did not return the default value")
+	}
+
+	m.Apply(func(p Pair[int, int]) Pair[int, int] { return NewPair(p.Key()%2, p.Val()) })
+	kvs := [][2]int(nil)
+	for k, v := range m.KeyValues() {
+		kvs = append(kvs, [2]int{k, v})
+	}
+	if want := [][2]int{{1, 20}, {0, 10}}; !reflect.DeepEqual(kvs, want) {
+		t.Errorf("Apply() resulted in: %v, but wanted %v", kvs, want)
+	}
+
+	rev := []int(nil)
+	for p := range m.ValuesRev() {
+		rev = append(rev, p.Key())
+	}
+	if !reflect.DeepEqual(rev, []int{0, 1}) {
+		t.Errorf("ValuesRev() keys = %v", rev)
+	}
+	if got := len(slices.Collect(m.Values())); got != 2 {
+		t.Errorf("Values() yielded %d pairs, want 2", got)
+	}
+}
+
+func Test_comfyBoundedMap_Apply_keepsPolicyState(t *testing.T) {
+	m := NewBoundedMap[string, int](3, NewLRUPolicy[string]())
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("c", 3)
+	m.Get("a")
+
+	m.Apply(func(p Pair[string, int]) Pair[string, int] { return NewPair(p.Key(), p.Val()*10) })
+	m.Set("d", 4)
+
+	kvs := [][2]any(nil)
+	for k, v := range m.KeyValues() {
+		kvs = append(kvs, [2]any{k, v})
+	}
+	if want := [][2]any{{"a", 10}, {"c", 30}, {"d", 4}}; !reflect.DeepEqual(kvs, want) {
+		t.Errorf("Set() after Apply() resulted in: %v, but wanted %v", kvs, want)
+	}
+}
+
+func Test_comfyBoundedMap_copy(t *testing.T) {
+	c1 := NewBoundedMap[string, int](2, NewLRUPolicy[string]())
+	c1.Set("a", 1)
+	c1.Set("b", 2)
+	c2 := Copy(c1)
+
+	c1.Get("a")
+	c1.Set("c", 3)
+	c2.Set("c", 3)
+
+	if got := slices.Sorted(c1.Keys()); !reflect.DeepEqual(got, []string{"a", "c"}) {
+		t.Errorf("original Keys() = %v", got)
+	}
+	if got := slices.Sorted(c2.Keys()); !reflect.DeepEqual(got, []string{"b", "c"}) {
+		t.Errorf("copy Keys() = %v, the policy was not copied", got)
+	}
+}
+
+func Test_comfyBoundedMap_keyIndex(t *testing.T) {
+	m := NewBoundedMap[int, int](100, NewLRUPolicy[int]()).(*comfyBoundedMap[int, int])
+	for i := range 10_000 {
+		m.Set(i, i)
+		if i%7 == 0 {
+			m.Remove(i - 3)
+		}
+	}
+	m.Sort(func(a, b Pair[int, int]) int { return b.Val() - a.Val() })
+	m.Remove(9_999)
+
+	if len(m.m) != m.l.Len() || m.Len() != 99 {
+		t.Fatalf("key index has %d keys, list has %d pairs, want 99", len(m.m), m.l.Len())
+	}
+	for n := m.l.head; n != nil; n = n.next {
+		if m.m[n.v.Key()] != n {
+			t.Fatalf("key index does not point at the node of key %d", n.v.Key())
+		}
+	}
+	if first, _ := m.At(0); first.Key() != 9_998 {
+		t.Errorf("At(0) after Sort() = %v, want key 9998", first)
+	}
+}
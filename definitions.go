@@ -16,6 +16,15 @@ var (
 
 	// ErrValueNotFound is returned when a value is not found in a collection.
 	ErrValueNotFound = errors.New("value not found")
+
+	// ErrCollectionFull is returned when a value cannot be added because a bounded collection is full.
+	ErrCollectionFull = errors.New("collection is full")
+
+	// ErrDuplicateValue is returned when a value cannot be added because it must be unique and is already present.
+	ErrDuplicateValue = errors.New("duplicate value")
+
+	// ErrDuplicateKey is returned when a key cannot be added because it is already present.
+	ErrDuplicateKey = errors.New("duplicate key")
 )
 
 // Predicate is used to verify that collection element meets certain conditions.
@@ -170,6 +179,9 @@ type OrderedMutable[V any] interface {
 // but slower insertion and removal time, making it suitable for situations where fast random access is needed.
 type Sequence[V any] interface {
 	OrderedMutable[V]
+
+	// Sort sorts the collection using the given comparator.
+	Sort(cmp Comparator[V])
 }
 
 // CmpSequence is a ordered collection of elements that can be compared.
@@ -178,12 +190,24 @@ type CmpSequence[V cmp.Ordered] interface {
 	CmpMutable[V]
 }
 
-// List is a mutable collection of elements.
+// List is a mutable collection of elements backed by a doubly linked list.
+//
+// Compared to a Sequence, a List allows for efficient O(1) insertion and removal at both ends
+// but slower O(n) access to arbitrary elements, making it suitable for queue-like workloads.
 type List[V any] interface {
 	OrderedMutable[V]
 
 	// InsertAt inserts the given value at the given index.
 	InsertAt(i int, val V) error
+
+	// Sort sorts the collection using the given comparator.
+	Sort(cmp Comparator[V])
+}
+
+// CmpList is a linked list of elements that can be compared.
+type CmpList[V cmp.Ordered] interface {
+	List[V]
+	CmpMutable[V]
 }
 
 // CmpOrdered is a list of elements of type cmp.Ordered
@@ -53,6 +53,25 @@ type cmpBaseMutableInternal[B any, V cmp.Ordered] interface {
 	CmpMutable[V]
 }
 
+// filterInternal is implemented by collections that can create a filtered copy of themselves
+// without copying the elements that are filtered out.
+type filterInternal[V any] interface {
+	baseInternal[V]
+	filter(predicate Predicate[V]) baseInternal[V]
+}
+
+// remapInternal is implemented by collections that can create a mapped copy of themselves in a single pass.
+type remapInternal[V any] interface {
+	baseInternal[V]
+	remap(f Mapper[V]) baseInternal[V]
+}
+
+// sortable is implemented by ordered collections that can be sorted in place with a comparator.
+type sortable[V any] interface {
+	OrderedMutable[V]
+	Sort(cmp Comparator[V])
+}
+
 type orderedMutableInternal[V any] interface {
 	OrderedMutable[V]
 	baseInternal[V]
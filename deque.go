@@ -0,0 +1,274 @@
+package coll
+
+import (
+	"iter"
+	"slices"
+)
+
+// Deque is a double-ended queue backed by a growable ring buffer.
+//
+// Compared to a Sequence, a Deque allows for efficient O(1) insertion and removal at both ends
+// while still providing O(1) access to arbitrary elements.
+type Deque[V any] interface {
+	Sequence[V]
+	IndexedMutable[V]
+
+	// PeekBack returns the last element without removing it.
+	// Returns ErrEmptyCollection if the deque is empty.
+	PeekBack() (V, error)
+
+	// PeekFront returns the first element without removing it.
+	// Returns ErrEmptyCollection if the deque is empty.
+	PeekFront() (V, error)
+
+	// PopBack removes and returns the last element.
+	// Returns ErrEmptyCollection if the deque is empty.
+	PopBack() (V, error)
+
+	// PopFront removes and returns the first element.
+	// Returns ErrEmptyCollection if the deque is empty.
+	PopFront() (V, error)
+
+	// PushBack adds the given value at the end of the deque.
+	PushBack(v V)
+
+	// PushFront adds the given value at the beginning of the deque.
+	PushFront(v V)
+}
+
+const dequeMinCapacity = 8
+
+type comfyDeque[V any] struct {
+	buf  []V
+	head int
+	size int
+}
+
+// NewDeque creates a new Deque instance.
+func NewDeque[V any]() Deque[V] {
+	return &comfyDeque[V]{}
+}
+
+// NewDequeFrom creates a new Deque instance from a slice.
+func NewDequeFrom[V any](s []V) Deque[V] {
+	d := NewDeque[V]()
+	d.Append(s...)
+	return d
+}
+
+func (c *comfyDeque[V]) Append(v ...V) {
+	for _, v := range v {
+		c.PushBack(v)
+	}
+}
+
+func (c *comfyDeque[V]) AppendColl(coll Ordered[V]) {
+	c.Append(slices.Collect(coll.Values())...)
+}
+
+func (c *comfyDeque[V]) Apply(f Mapper[V]) {
+	for i := range c.size {
+		pos := c.pos(i)
+		c.buf[pos] = f(c.buf[pos])
+	}
+}
+
+func (c *comfyDeque[V]) At(i int) (V, bool) {
+	if i < 0 || i >= c.size {
+		var v V
+		return v, false
+	}
+	return c.buf[c.pos(i)], true
+}
+
+func (c *comfyDeque[V]) AtOrDefault(i int, defaultValue V) V {
+	if i < 0 || i >= c.size {
+		return defaultValue
+	}
+	return c.buf[c.pos(i)]
+}
+
+func (c *comfyDeque[V]) Clear() {
+	c.buf = []V(nil)
+	c.head = 0
+	c.size = 0
+}
+
+func (c *comfyDeque[V]) IsEmpty() bool {
+	return c.size == 0
+}
+
+func (c *comfyDeque[V]) Len() int {
+	return c.size
+}
+
+func (c *comfyDeque[V]) PeekBack() (V, error) {
+	if c.size == 0 {
+		var v V
+		return v, ErrEmptyCollection
+	}
+	return c.buf[c.pos(c.size-1)], nil
+}
+
+func (c *comfyDeque[V]) PeekFront() (V, error) {
+	if c.size == 0 {
+		var v V
+		return v, ErrEmptyCollection
+	}
+	return c.buf[c.head], nil
+}
+
+func (c *comfyDeque[V]) PopBack() (V, error) {
+	if c.size == 0 {
+		var v V
+		return v, ErrEmptyCollection
+	}
+	pos := c.pos(c.size - 1)
+	v := c.buf[pos]
+	var zero V
+	c.buf[pos] = zero
+	c.size--
+	return v, nil
+}
+
+func (c *comfyDeque[V]) PopFront() (V, error) {
+	if c.size == 0 {
+		var v V
+		return v, ErrEmptyCollection
+	}
+	v := c.buf[c.head]
+	var zero V
+	c.buf[c.head] = zero
+	c.head = c.pos(1)
+	c.size--
+	return v, nil
+}
+
+func (c *comfyDeque[V]) Prepend(v ...V) {
+	for i := len(v) - 1; i >= 0; i-- {
+		c.PushFront(v[i])
+	}
+}
+
+func (c *comfyDeque[V]) PushBack(v V) {
+	c.grow()
+	c.buf[c.pos(c.size)] = v
+	c.size++
+}
+
+func (c *comfyDeque[V]) PushFront(v V) {
+	c.grow()
+	c.head = (c.head - 1 + len(c.buf)) % len(c.buf)
+	c.buf[c.head] = v
+	c.size++
+}
+
+func (c *comfyDeque[V]) RemoveAt(i int) (removed V, err error) {
+	if i < 0 || i >= c.size {
+		return removed, ErrOutOfBounds
+	}
+
+	removed = c.buf[c.pos(i)]
+	if i < c.size/2 {
+		for j := i; j > 0; j-- {
+			c.buf[c.pos(j)] = c.buf[c.pos(j-1)]
+		}
+		_, _ = c.PopFront()
+	} else {
+		for j := i; j < c.size-1; j++ {
+			c.buf[c.pos(j)] = c.buf[c.pos(j+1)]
+		}
+		_, _ = c.PopBack()
+	}
+
+	return removed, nil
+}
+
+func (c *comfyDeque[V]) RemoveMatching(predicate Predicate[V]) (count int) {
+	kept := 0
+	for i := range c.size {
+		v := c.buf[c.pos(i)]
+		if predicate(v) {
+			count++
+			continue
+		}
+		c.buf[c.pos(kept)] = v
+		kept++
+	}
+	for c.size > kept {
+		_, _ = c.PopBack()
+	}
+	return count
+}
+
+func (c *comfyDeque[V]) Reverse() {
+	c.linearize()
+	slices.Reverse(c.buf[:c.size])
+}
+
+func (c *comfyDeque[V]) Sort(cmp func(a, b V) int) {
+	c.linearize()
+	slices.SortFunc(c.buf[:c.size], cmp)
+}
+
+func (c *comfyDeque[V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for i := range c.size {
+			if !yield(c.buf[c.pos(i)]) {
+				break
+			}
+		}
+	}
+}
+
+func (c *comfyDeque[V]) ValuesRev() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for i := c.size - 1; i >= 0; i-- {
+			if !yield(c.buf[c.pos(i)]) {
+				break
+			}
+		}
+	}
+}
+
+// Private:
+
+//nolint:unused
+func (c *comfyDeque[V]) copy() baseInternal[V] {
+	return &comfyDeque[V]{
+		buf:  slices.Clone(c.buf),
+		head: c.head,
+		size: c.size,
+	}
+}
+
+// grow makes room for at least one more element, doubling the buffer when it is full.
+func (c *comfyDeque[V]) grow() {
+	if c.size < len(c.buf) {
+		return
+	}
+	newBuf := make([]V, max(dequeMinCapacity, 2*len(c.buf)))
+	for i := range c.size {
+		newBuf[i] = c.buf[c.pos(i)]
+	}
+	c.buf = newBuf
+	c.head = 0
+}
+
+// linearize rotates the buffer so that the first element is at index 0.
+func (c *comfyDeque[V]) linearize() {
+	if c.head == 0 {
+		return
+	}
+	newBuf := make([]V, len(c.buf))
+	for i := range c.size {
+		newBuf[i] = c.buf[c.pos(i)]
+	}
+	c.buf = newBuf
+	c.head = 0
+}
+
+// pos translates a logical index into a position in the underlying buffer.
+func (c *comfyDeque[V]) pos(i int) int {
+	return (c.head + i) % len(c.buf)
+}
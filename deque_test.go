@@ -0,0 +1,265 @@
+package coll
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+type comfyDequeIntBuilder[C baseInternal[int]] struct {
+}
+
+func (lcb *comfyDequeIntBuilder[C]) Empty() C {
+	return lcb.make([]int(nil)).(C)
+}
+
+func (lcb *comfyDequeIntBuilder[C]) One() C {
+	return lcb.make([]int{111}).(C)
+}
+
+func (lcb *comfyDequeIntBuilder[C]) Two() C {
+	return lcb.make([]int{123, 234}).(C)
+}
+
+func (lcb *comfyDequeIntBuilder[C]) Three() C {
+	return lcb.make([]int{111, 222, 333}).(C)
+}
+
+func (lcb *comfyDequeIntBuilder[C]) ThreeRev() C {
+	return lcb.make([]int{333, 222, 111}).(C)
+}
+
+func (lcb *comfyDequeIntBuilder[C]) SixWithDuplicates() C {
+	return lcb.make([]int{111, 222, 333, 111, 222, 333}).(C)
+}
+
+func (lcb *comfyDequeIntBuilder[C]) FromValues(values []any) C {
+	c := lcb.make([]int{})
+	for _, v := range values {
+		c.Append(v.(int))
+	}
+	return c.(C)
+}
+
+// make builds a deque whose contents wrap around the end of the underlying buffer,
+// so that the shared test cases also exercise index translation.
+func (lcb *comfyDequeIntBuilder[C]) make(items []int) orderedMutableInternal[int] {
+	coll := &comfyDeque[int]{}
+	for range dequeMinCapacity - 1 {
+		coll.PushBack(0)
+	}
+	for range dequeMinCapacity - 1 {
+		_, _ = coll.PopFront()
+	}
+	coll.Append(items...)
+
+	return coll
+}
+
+func (lcb *comfyDequeIntBuilder[C]) extractRawValues(c C) any {
+	return lcb.extractUnderlyingSlice(c)
+}
+
+func (lcb *comfyDequeIntBuilder[C]) extractUnderlyingSlice(c C) any {
+	return slices.Collect((any(c)).(*comfyDeque[int]).Values())
+}
+
+func (lcb *comfyDequeIntBuilder[C]) extractUnderlyingMap(_ C) any {
+	return nil
+}
+
+func (lcb *comfyDequeIntBuilder[C]) extractUnderlyingKp(_ C) any {
+	return nil
+}
+
+func (lcb *comfyDequeIntBuilder[C]) extractUnderlyingValsCount(_ C) any {
+	return nil
+}
+
+func TestNewDeque(t *testing.T) {
+	d := NewDeque[int]()
+	if d == nil {
+		t.Error("NewDeque[int]() returned nil")
+	}
+	if !reflect.DeepEqual(d, &comfyDeque[int]{}) {
+		t.Error("NewDeque[int]() did not return a comfyDeque[int]")
+	}
+}
+
+func TestNewDequeFrom(t *testing.T) {
+	d := NewDequeFrom([]int{1, 2, 3})
+	if got := slices.Collect(d.Values()); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Errorf("NewDequeFrom[int]() resulted in: %v, but wanted %v", got, []int{1, 2, 3})
+	}
+	if d.Len() != 3 {
+		t.Errorf("NewDequeFrom[int]() resulted in length %d, but wanted 3", d.Len())
+	}
+}
+
+func Test_comfyDeque_Append(t *testing.T) {
+	testAppendOne(t, &comfyDequeIntBuilder[orderedMutableInternal[int]]{})
+	testAppendMany(t, &comfyDequeIntBuilder[orderedMutableInternal[int]]{})
+}
+
+func Test_comfyDeque_AppendColl(t *testing.T) {
+	testAppendColl(t, &comfyDequeIntBuilder[orderedMutableInternal[int]]{})
+}
+
+func Test_comfyDeque_Apply(t *testing.T) {
+	testApply(t, &comfyDequeIntBuilder[mutableInternal[int]]{})
+}
+
+func Test_comfyDeque_At(t *testing.T) {
+	testAt(t, &comfyDequeIntBuilder[indexedInternal[int]]{})
+}
+
+func Test_comfyDeque_AtOrDefault(t *testing.T) {
+	testAtOrDefault(t, &comfyDequeIntBuilder[indexedInternal[int]]{})
+}
+
+func Test_comfyDeque_Clear(t *testing.T) {
+	testClear(t, &comfyDequeIntBuilder[mutableInternal[int]]{})
+}
+
+func Test_comfyDeque_IsEmpty(t *testing.T) {
+	testIsEmpty(t, &comfyDequeIntBuilder[baseInternal[int]]{})
+}
+
+func Test_comfyDeque_Len(t *testing.T) {
+	testLen(t, &comfyDequeIntBuilder[baseInternal[int]]{})
+}
+
+func Test_comfyDeque_Prepend(t *testing.T) {
+	testPrependOne(t, &comfyDequeIntBuilder[orderedMutableInternal[int]]{})
+	testPrependMany(t, &comfyDequeIntBuilder[orderedMutableInternal[int]]{})
+}
+
+func Test_comfyDeque_RemoveAt(t *testing.T) {
+	testRemoveAt(t, &comfyDequeIntBuilder[indexedMutableInternal[int]]{})
+}
+
+func Test_comfyDeque_RemoveMatching(t *testing.T) {
+	testRemoveMatching(t, &comfyDequeIntBuilder[mutableInternal[int]]{})
+}
+
+func Test_comfyDeque_Reverse(t *testing.T) {
+	testReverse(t, &comfyDequeIntBuilder[orderedMutableInternal[int]]{})
+	testReverseTwice(t, &comfyDequeIntBuilder[orderedMutableInternal[int]]{})
+}
+
+func Test_comfyDeque_Sort(t *testing.T) {
+	testSort(t, &comfyDequeIntBuilder[indexedMutableInternal[int]]{})
+}
+
+func Test_comfyDeque_Values(t *testing.T) {
+	testValues(t, &comfyDequeIntBuilder[baseInternal[int]]{})
+	testValuesBreak(t, &comfyDequeIntBuilder[baseInternal[int]]{})
+}
+
+func Test_comfyDeque_ValuesRev(t *testing.T) {
+	testValuesRev(t, &comfyDequeIntBuilder[orderedInternal[int]]{})
+	testValuesRevBreak(t, &comfyDequeIntBuilder[orderedInternal[int]]{})
+}
+
+func Test_comfyDeque_copy(t *testing.T) {
+	testCopy(t, &comfyDequeIntBuilder[baseInternal[int]]{})
+}
+
+func Test_comfyDeque_copy_pointer(t *testing.T) {
+	c1 := NewDequeFrom([]int{123, 234, 345})
+	c2 := Copy(c1)
+
+	c1.PushBack(999)
+	c1.Apply(func(v int) int { return -v })
+	if got := slices.Collect(c2.Values()); !reflect.DeepEqual(got, []int{123, 234, 345}) {
+		t.Errorf("copy() did not create a deep copy, got %v", got)
+	}
+}
+
+func Test_comfyDeque_PushPop(t *testing.T) {
+	d := NewDeque[int]()
+	for i := range 20 {
+		d.PushBack(i)
+		d.PushFront(-i - 1)
+	}
+
+	if d.Len() != 40 {
+		t.Fatalf("Len() = %d, want 40", d.Len())
+	}
+	if v, err := d.PeekFront(); err != nil || v != -20 {
+		t.Errorf("PeekFront() = %v, %v, want -20, nil", v, err)
+	}
+	if v, err := d.PeekBack(); err != nil || v != 19 {
+		t.Errorf("PeekBack() = %v, %v, want 19, nil", v, err)
+	}
+
+	for i := 19; i >= 0; i-- {
+		if v, err := d.PopBack(); err != nil || v != i {
+			t.Fatalf("PopBack() = %v, %v, want %v, nil", v, err, i)
+		}
+		if v, err := d.PopFront(); err != nil || v != -i-1 {
+			t.Fatalf("PopFront() = %v, %v, want %v, nil", v, err, -i-1)
+		}
+	}
+
+	if !d.IsEmpty() {
+		t.Errorf("IsEmpty() = false after popping all values")
+	}
+}
+
+func Test_comfyDeque_PushPop_empty(t *testing.T) {
+	d := NewDeque[int]()
+	cases := []struct {
+		name string
+		f    func() (int, error)
+	}{
+		{name: "PeekBack()", f: d.PeekBack},
+		{name: "PeekFront()", f: d.PeekFront},
+		{name: "PopBack()", f: d.PopBack},
+		{name: "PopFront()", f: d.PopFront},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name+" on empty deque", func(t *testing.T) {
+			v, err := tt.f()
+			if !errors.Is(err, ErrEmptyCollection) {
+				t.Errorf("%s returned error %v, want ErrEmptyCollection", tt.name, err)
+			}
+			if v != 0 {
+				t.Errorf("%s returned %v, want zero value", tt.name, v)
+			}
+		})
+	}
+}
+
+func Test_comfyDeque_wrapAround(t *testing.T) {
+	d := NewDeque[int]().(*comfyDeque[int])
+	want := []int(nil)
+	for i := range 100 {
+		d.PushBack(i)
+		want = append(want, i)
+		if i%3 == 0 {
+			_, _ = d.PopFront()
+			want = want[1:]
+		}
+	}
+
+	if got := slices.Collect(d.Values()); !reflect.DeepEqual(got, want) {
+		t.Errorf("Values() = %v, want %v", got, want)
+	}
+	for i, v := range want {
+		if got, ok := d.At(i); !ok || got != v {
+			t.Fatalf("At(%d) = %v, %v, want %v, true", i, got, ok, v)
+		}
+	}
+
+	mid := len(want) / 2
+	removed, err := d.RemoveAt(mid)
+	if err != nil || removed != want[mid] {
+		t.Errorf("RemoveAt(%d) = %v, %v, want %v, nil", mid, removed, err, want[mid])
+	}
+	want = slices.Delete(want, mid, mid+1)
+	if got := slices.Collect(d.Values()); !reflect.DeepEqual(got, want) {
+		t.Errorf("Values() after RemoveAt() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,186 @@
+package coll
+
+import (
+	"iter"
+)
+
+// DisjointSet (also known as union-find) keeps track of elements partitioned into non-overlapping groups.
+// Every element starts in a group of its own, and groups are merged with Union.
+// It uses path compression and union by rank, so Find, Union and Connected run in nearly constant amortized time.
+//
+// Values iterates over all elements in the order in which they were added.
+type DisjointSet[V comparable] interface {
+	Base[V]
+
+	// Connected returns true if both elements are in the same group.
+	// Returns false if either of the elements is not present.
+	Connected(a, b V) bool
+
+	// Find returns the representative element of the group containing the given element.
+	// Returns ErrValueNotFound if the element is not present.
+	Find(v V) (V, error)
+
+	// Groups returns an iterator over all groups.
+	// Groups are ordered by their earliest added element, and elements within a group keep the order in which
+	// they were added.
+	Groups() iter.Seq[Sequence[V]]
+
+	// MakeSet adds the given elements, each in a new group of its own.
+	// Elements that are already present are left unchanged.
+	MakeSet(v ...V)
+
+	// SetCount returns the number of groups.
+	SetCount() int
+
+	// Union merges the groups containing both elements.
+	// Returns false if the elements were already in the same group.
+	// Returns ErrValueNotFound if either of the elements is not present.
+	Union(a, b V) (merged bool, err error)
+}
+
+type comfyDisjointSet[V comparable] struct {
+	s      []V
+	kp     map[V]int
+	parent []int
+	rank   []int
+	sets   int
+}
+
+// NewDisjointSet creates a new DisjointSet instance.
+func NewDisjointSet[V comparable]() DisjointSet[V] {
+	return &comfyDisjointSet[V]{
+		s:  []V(nil),
+		kp: make(map[V]int),
+	}
+}
+
+// NewDisjointSetFrom creates a new DisjointSet instance from a slice.
+// Each distinct value is put in a group of its own.
+func NewDisjointSetFrom[V comparable](s []V) DisjointSet[V] {
+	ds := NewDisjointSet[V]()
+	ds.MakeSet(s...)
+	return ds
+}
+
+func (c *comfyDisjointSet[V]) Connected(a, b V) bool {
+	ia, okA := c.kp[a]
+	ib, okB := c.kp[b]
+	return okA && okB && c.find(ia) == c.find(ib)
+}
+
+func (c *comfyDisjointSet[V]) Find(v V) (V, error) {
+	i, ok := c.kp[v]
+	if !ok {
+		var zero V
+		return zero, ErrValueNotFound
+	}
+	return c.s[c.find(i)], nil
+}
+
+func (c *comfyDisjointSet[V]) Groups() iter.Seq[Sequence[V]] {
+	return func(yield func(Sequence[V]) bool) {
+		groups := make(map[int]Sequence[V], c.sets)
+		roots := make([]int, 0, c.sets)
+		for i, v := range c.s {
+			root := c.find(i)
+			g, ok := groups[root]
+			if !ok {
+				g = NewSequence[V]()
+				groups[root] = g
+				roots = append(roots, root)
+			}
+			g.Append(v)
+		}
+		for _, root := range roots {
+			if !yield(groups[root]) {
+				break
+			}
+		}
+	}
+}
+
+func (c *comfyDisjointSet[V]) IsEmpty() bool {
+	return len(c.s) == 0
+}
+
+func (c *comfyDisjointSet[V]) Len() int {
+	return len(c.s)
+}
+
+func (c *comfyDisjointSet[V]) MakeSet(v ...V) {
+	for _, v := range v {
+		if _, exists := c.kp[v]; exists {
+			continue
+		}
+		c.kp[v] = len(c.s)
+		c.parent = append(c.parent, len(c.s))
+		c.rank = append(c.rank, 0)
+		c.s = append(c.s, v)
+		c.sets++
+	}
+}
+
+func (c *comfyDisjointSet[V]) SetCount() int {
+	return c.sets
+}
+
+func (c *comfyDisjointSet[V]) Union(a, b V) (merged bool, err error) {
+	ia, okA := c.kp[a]
+	ib, okB := c.kp[b]
+	if !okA || !okB {
+		return false, ErrValueNotFound
+	}
+
+	ra, rb := c.find(ia), c.find(ib)
+	if ra == rb {
+		return false, nil
+	}
+	if c.rank[ra] < c.rank[rb] {
+		ra, rb = rb, ra
+	}
+	c.parent[rb] = ra
+	if c.rank[ra] == c.rank[rb] {
+		c.rank[ra]++
+	}
+	c.sets--
+	return true, nil
+}
+
+func (c *comfyDisjointSet[V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range c.s {
+			if !yield(v) {
+				break
+			}
+		}
+	}
+}
+
+// Private:
+
+//nolint:unused
+func (c *comfyDisjointSet[V]) copy() baseInternal[V] {
+	newDs := &comfyDisjointSet[V]{
+		s:      append([]V(nil), c.s...),
+		kp:     make(map[V]int, len(c.kp)),
+		parent: append([]int(nil), c.parent...),
+		rank:   append([]int(nil), c.rank...),
+		sets:   c.sets,
+	}
+	for v, i := range c.kp {
+		newDs.kp[v] = i
+	}
+	return newDs
+}
+
+// find returns the position of the root of the given element, compressing the path on the way.
+func (c *comfyDisjointSet[V]) find(i int) int {
+	root := i
+	for c.parent[root] != root {
+		root = c.parent[root]
+	}
+	for c.parent[i] != root {
+		c.parent[i], i = root, c.parent[i]
+	}
+	return root
+}
@@ -0,0 +1,200 @@
+package coll
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func disjointSetGroups[V comparable](ds DisjointSet[V]) [][]V {
+	groups := [][]V(nil)
+	for g := range ds.Groups() {
+		groups = append(groups, slices.Collect(g.Values()))
+	}
+	return groups
+}
+
+func TestNewDisjointSet(t *testing.T) {
+	ds := NewDisjointSet[int]()
+	if ds == nil {
+		t.Fatal("NewDisjointSet[int]() returned nil")
+	}
+	if !reflect.DeepEqual(ds, &comfyDisjointSet[int]{s: []int(nil), kp: make(map[int]int)}) {
+		t.Error("NewDisjointSet[int]() did not return an empty comfyDisjointSet[int]")
+	}
+}
+
+func TestNewDisjointSetFrom(t *testing.T) {
+	ds := NewDisjointSetFrom([]string{"a", "b", "a", "c"})
+	if ds.Len() != 3 || ds.SetCount() != 3 {
+		t.Errorf("Len() = %d, SetCount() = %d, want 3, 3", ds.Len(), ds.SetCount())
+	}
+	if got := slices.Collect(ds.Values()); !reflect.DeepEqual(got, []string{"a", "b", "c"}) {
+		t.Errorf("Values() = %v", got)
+	}
+}
+
+func Test_comfyDisjointSet_Union(t *testing.T) {
+	ds := NewDisjointSetFrom([]string{"a", "b", "c", "d", "e"})
+
+	cases := []struct {
+		name       string
+		a, b       string
+		wantMerged bool
+		wantErr    error
+		wantSets   int
+	}{
+		{name: "Union() of two singletons", a: "a", b: "b", wantMerged: true, wantSets: 4},
+		{name: "Union() of two other singletons", a: "d", b: "c", wantMerged: true, wantSets: 3},
+		{name: "Union() of two groups", a: "b", b: "c", wantMerged: true, wantSets: 2},
+		{name: "Union() within a group", a: "a", b: "d", wantMerged: false, wantSets: 2},
+		{name: "Union() with itself", a: "e", b: "e", wantMerged: false, wantSets: 2},
+		{name: "Union() with missing element", a: "a", b: "x", wantErr: ErrValueNotFound, wantSets: 2},
+		{name: "Union() with missing first element", a: "x", b: "a", wantErr: ErrValueNotFound, wantSets: 2},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, err := ds.Union(tt.a, tt.b)
+			if merged != tt.wantMerged || !errors.Is(err, tt.wantErr) {
+				t.Errorf("Union(%q, %q) = %v, %v, want %v, %v", tt.a, tt.b, merged, err, tt.wantMerged, tt.wantErr)
+			}
+			if ds.SetCount() != tt.wantSets {
+				t.Errorf("SetCount() = %d, want %d", ds.SetCount(), tt.wantSets)
+			}
+		})
+	}
+
+	if want := [][]string{{"a", "b", "c", "d"}, {"e"}}; !reflect.DeepEqual(disjointSetGroups(ds), want) {
+		t.Errorf("Groups() = %v, want %v", disjointSetGroups(ds), want)
+	}
+}
+
+func Test_comfyDisjointSet_FindAndConnected(t *testing.T) {
+	ds := NewDisjointSetFrom([]int{1, 2, 3, 4})
+	_, _ = ds.Union(1, 2)
+	_, _ = ds.Union(3, 2)
+
+	r1, err1 := ds.Find(1)
+	r3, err3 := ds.Find(3)
+	if err1 != nil || err3 != nil || r1 != r3 {
+		t.Errorf("Find(1) = %v, %v and Find(3) = %v, %v, want the same representative", r1, err1, r3, err3)
+	}
+	if r4, _ := ds.Find(4); r4 != 4 {
+		t.Errorf("Find(4) = %v, want 4", r4)
+	}
+	if _, err := ds.Find(5); !errors.Is(err, ErrValueNotFound) {
+		t.Errorf("Find(5) returned error %v, want ErrValueNotFound", err)
+	}
+
+	cases := []struct {
+		a, b int
+		want bool
+	}{
+		{a: 1, b: 3, want: true},
+		{a: 2, b: 2, want: true},
+		{a: 1, b: 4, want: false},
+		{a: 1, b: 5, want: false},
+		{a: 5, b: 5, want: false},
+	}
+	for _, tt := range cases {
+		if got := ds.Connected(tt.a, tt.b); got != tt.want {
+			t.Errorf("Connected(%d, %d) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func Test_comfyDisjointSet_MakeSet(t *testing.T) {
+	ds := NewDisjointSetFrom([]int{1, 2})
+	_, _ = ds.Union(1, 2)
+	ds.MakeSet(2, 3, 3)
+
+	if ds.Len() != 3 || ds.SetCount() != 2 {
+		t.Errorf("Len() = %d, SetCount() = %d, want 3, 2", ds.Len(), ds.SetCount())
+	}
+	if !ds.Connected(1, 2) {
+		t.Error("MakeSet() of an existing element split its group")
+	}
+}
+
+func Test_comfyDisjointSet_Groups(t *testing.T) {
+	ds := NewDisjointSetFrom([]int{1, 2, 3, 4, 5, 6})
+	_, _ = ds.Union(6, 2)
+	_, _ = ds.Union(5, 1)
+	_, _ = ds.Union(4, 6)
+
+	if want := [][]int{{1, 5}, {2, 4, 6}, {3}}; !reflect.DeepEqual(disjointSetGroups(ds), want) {
+		t.Errorf("Groups() = %v, want %v", disjointSetGroups(ds), want)
+	}
+	if groups := disjointSetGroups(NewDisjointSet[int]()); groups != nil {
+		t.Errorf("Groups() on empty set = %v", groups)
+	}
+
+	count := 0
+	for range ds.Groups() {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Errorf("Groups() did not stop, count = %d", count)
+	}
+}
+
+func Test_comfyDisjointSet_randomUnions(t *testing.T) {
+	const n = 200
+	rnd := rand.New(rand.NewSource(42))
+	ds := NewDisjointSet[int]().(*comfyDisjointSet[int])
+	reference := make([]int, n)
+	for i := range n {
+		ds.MakeSet(i)
+		reference[i] = i
+	}
+
+	for range 150 {
+		a, b := rnd.Intn(n), rnd.Intn(n)
+		merged, _ := ds.Union(a, b)
+		if merged != (reference[a] != reference[b]) {
+			t.Fatalf("Union(%d, %d) = %v", a, b, merged)
+		}
+		from, to := reference[b], reference[a]
+		for i := range reference {
+			if reference[i] == from {
+				reference[i] = to
+			}
+		}
+	}
+
+	distinct := map[int]bool{}
+	for i := range n {
+		distinct[reference[i]] = true
+		for j := range n {
+			if got := ds.Connected(i, j); got != (reference[i] == reference[j]) {
+				t.Fatalf("Connected(%d, %d) = %v", i, j, got)
+			}
+		}
+	}
+	if ds.SetCount() != len(distinct) {
+		t.Errorf("SetCount() = %d, want %d", ds.SetCount(), len(distinct))
+	}
+	for i := range n {
+		if root := ds.find(i); ds.parent[i] != root {
+			t.Fatalf("path to %d was not compressed", i)
+		}
+	}
+}
+
+func Test_comfyDisjointSet_copy(t *testing.T) {
+	c1 := NewDisjointSetFrom([]int{1, 2, 3})
+	_, _ = c1.Union(1, 2)
+	c2 := Copy(c1)
+
+	if !reflect.DeepEqual(c1, c2) {
+		t.Errorf("Copy() = %v, want %v", c2, c1)
+	}
+	_, _ = c1.Union(2, 3)
+	c1.MakeSet(4)
+	if c2.Connected(1, 3) || c2.Len() != 3 || c2.SetCount() != 2 {
+		t.Error("copy() did not create a deep copy")
+	}
+}
@@ -0,0 +1,350 @@
+package coll
+
+// EvictionPolicy decides which key a capacity-bounded collection evicts when it runs out of space.
+// The collection notifies the policy about every key it stores, reads and removes,
+// so one policy instance must not be shared between collections.
+// Policies that need to know the capacity of the collection can implement CapacityAware.
+type EvictionPolicy[K comparable] interface {
+	// Access records a use of a key that is already stored.
+	Access(k K)
+
+	// Clear forgets all keys.
+	Clear()
+
+	// Clone returns an independent copy of the policy with the same state.
+	Clone() EvictionPolicy[K]
+
+	// Insert records that a new key was stored.
+	Insert(k K)
+
+	// Remove forgets the given key after it was removed from the collection.
+	Remove(k K)
+
+	// Victim selects the key to evict to make room for the incoming key and stops tracking it as stored.
+	// Returns false if the policy does not track any stored keys.
+	Victim(incoming K) (K, bool)
+}
+
+// CapacityAware is implemented by eviction policies that need to know the capacity of the collection they serve.
+type CapacityAware interface {
+	// SetCapacity is called by the collection with its capacity before the policy is used.
+	SetCapacity(capacity int)
+}
+
+// lruPolicy evicts the least recently used key.
+type lruPolicy[K comparable] struct {
+	l     *comfyList[K]
+	nodes map[K]*comfyListNode[K]
+}
+
+// NewLRUPolicy creates an EvictionPolicy that evicts the least recently used key.
+func NewLRUPolicy[K comparable]() EvictionPolicy[K] {
+	return &lruPolicy[K]{
+		l:     &comfyList[K]{},
+		nodes: make(map[K]*comfyListNode[K]),
+	}
+}
+
+func (p *lruPolicy[K]) Access(k K) {
+	if n, ok := p.nodes[k]; ok {
+		p.l.moveToBack(n)
+	}
+}
+
+func (p *lruPolicy[K]) Clear() {
+	p.l.Clear()
+	p.nodes = make(map[K]*comfyListNode[K])
+}
+
+func (p *lruPolicy[K]) Clone() EvictionPolicy[K] {
+	clone := NewLRUPolicy[K]().(*lruPolicy[K])
+	for k := range p.l.Values() {
+		clone.nodes[k] = clone.l.pushBack(k)
+	}
+	return clone
+}
+
+func (p *lruPolicy[K]) Insert(k K) {
+	if n, ok := p.nodes[k]; ok {
+		p.l.moveToBack(n)
+		return
+	}
+	p.nodes[k] = p.l.pushBack(k)
+}
+
+func (p *lruPolicy[K]) Remove(k K) {
+	if n, ok := p.nodes[k]; ok {
+		p.l.unlink(n)
+		delete(p.nodes, k)
+	}
+}
+
+func (p *lruPolicy[K]) Victim(_ K) (K, bool) {
+	if p.l.head == nil {
+		var k K
+		return k, false
+	}
+	k := p.l.head.v
+	p.Remove(k)
+	return k, true
+}
+
+// lfuPolicy evicts the least frequently used key, breaking ties by evicting the least recently used one.
+// Keys are kept in per-frequency buckets, so that all operations are O(1).
+type lfuPolicy[K comparable] struct {
+	freq    *valuesCounter[K]
+	buckets map[int]*comfyList[K]
+	nodes   map[K]*comfyListNode[K]
+	minFreq int
+}
+
+// NewLFUPolicy creates an EvictionPolicy that evicts the least frequently used key.
+func NewLFUPolicy[K comparable]() EvictionPolicy[K] {
+	return &lfuPolicy[K]{
+		freq:    newValuesCounter[K](),
+		buckets: make(map[int]*comfyList[K]),
+		nodes:   make(map[K]*comfyListNode[K]),
+	}
+}
+
+func (p *lfuPolicy[K]) Access(k K) {
+	if _, ok := p.nodes[k]; !ok {
+		return
+	}
+	f := p.freq.Count(k)
+	p.unlink(k)
+	if p.minFreq == f && p.buckets[f] == nil {
+		p.minFreq = f + 1
+	}
+	p.freq.Increment(k)
+	p.link(k, f+1)
+}
+
+func (p *lfuPolicy[K]) Clear() {
+	p.freq = newValuesCounter[K]()
+	p.buckets = make(map[int]*comfyList[K])
+	p.nodes = make(map[K]*comfyListNode[K])
+	p.minFreq = 0
+}
+
+func (p *lfuPolicy[K]) Clone() EvictionPolicy[K] {
+	clone := NewLFUPolicy[K]().(*lfuPolicy[K])
+	for f, b := range p.buckets {
+		for k := range b.Values() {
+			clone.freq.Set(k, f)
+			clone.link(k, f)
+		}
+	}
+	clone.minFreq = p.minFreq
+	return clone
+}
+
+func (p *lfuPolicy[K]) Insert(k K) {
+	if _, ok := p.nodes[k]; ok {
+		p.Access(k)
+		return
+	}
+	p.freq.Set(k, 1)
+	p.link(k, 1)
+	p.minFreq = 1
+}
+
+func (p *lfuPolicy[K]) Remove(k K) {
+	if _, ok := p.nodes[k]; !ok {
+		return
+	}
+	p.unlink(k)
+	p.freq.Set(k, 0)
+}
+
+func (p *lfuPolicy[K]) Victim(_ K) (K, bool) {
+	if len(p.nodes) == 0 {
+		var k K
+		return k, false
+	}
+	if p.buckets[p.minFreq] == nil {
+		// The minimum is unknown after an explicit Remove emptied its bucket.
+		p.minFreq = 0
+		for f := range p.buckets {
+			if p.minFreq == 0 || f < p.minFreq {
+				p.minFreq = f
+			}
+		}
+	}
+	k := p.buckets[p.minFreq].head.v
+	p.Remove(k)
+	return k, true
+}
+
+// link adds the key at the back of the bucket of the given frequency.
+func (p *lfuPolicy[K]) link(k K, f int) {
+	b, ok := p.buckets[f]
+	if !ok {
+		b = &comfyList[K]{}
+		p.buckets[f] = b
+	}
+	p.nodes[k] = b.pushBack(k)
+}
+
+// unlink removes the key from its bucket, dropping the bucket when it gets empty.
+func (p *lfuPolicy[K]) unlink(k K) {
+	f := p.freq.Count(k)
+	b := p.buckets[f]
+	b.unlink(p.nodes[k])
+	delete(p.nodes, k)
+	if b.IsEmpty() {
+		delete(p.buckets, f)
+	}
+}
+
+// arcPolicy implements the Adaptive Replacement Cache algorithm.
+// It balances between recency (t1) and frequency (t2) using ghost lists (b1, b2) of recently evicted keys
+// to adapt the target size p of t1.
+//
+// A ghost hit adapts p before the victim is chosen, so whichever of Victim and Insert sees the incoming key first
+// does it, and adapted remembers the key so that it is not done twice.
+type arcPolicy[K comparable] struct {
+	t1, t2, b1, b2 *comfyList[K]
+	entries        map[K]arcEntry[K]
+	adapted        *K
+	p              int
+	capacity       int
+}
+
+type arcEntry[K comparable] struct {
+	n *comfyListNode[K]
+	l *comfyList[K]
+}
+
+// NewARCPolicy creates an EvictionPolicy implementing the Adaptive Replacement Cache algorithm.
+// The capacity is set by the collection that uses the policy.
+func NewARCPolicy[K comparable]() EvictionPolicy[K] {
+	return &arcPolicy[K]{
+		t1:      &comfyList[K]{},
+		t2:      &comfyList[K]{},
+		b1:      &comfyList[K]{},
+		b2:      &comfyList[K]{},
+		entries: make(map[K]arcEntry[K]),
+	}
+}
+
+func (p *arcPolicy[K]) Access(k K) {
+	e, ok := p.entries[k]
+	if !ok || (e.l != p.t1 && e.l != p.t2) {
+		return
+	}
+	p.move(k, p.t2)
+}
+
+func (p *arcPolicy[K]) Clear() {
+	p.t1.Clear()
+	p.t2.Clear()
+	p.b1.Clear()
+	p.b2.Clear()
+	p.entries = make(map[K]arcEntry[K])
+	p.adapted = nil
+	p.p = 0
+}
+
+func (p *arcPolicy[K]) Clone() EvictionPolicy[K] {
+	clone := NewARCPolicy[K]().(*arcPolicy[K])
+	clone.p = p.p
+	clone.capacity = p.capacity
+	if p.adapted != nil {
+		adapted := *p.adapted
+		clone.adapted = &adapted
+	}
+	pairs := []struct{ from, to *comfyList[K] }{
+		{p.t1, clone.t1}, {p.t2, clone.t2}, {p.b1, clone.b1}, {p.b2, clone.b2},
+	}
+	for _, lists := range pairs {
+		for k := range lists.from.Values() {
+			clone.entries[k] = arcEntry[K]{n: lists.to.pushBack(k), l: lists.to}
+		}
+	}
+	return clone
+}
+
+func (p *arcPolicy[K]) Insert(k K) {
+	p.adapt(k)
+	p.adapted = nil
+
+	e, ok := p.entries[k]
+	switch {
+	case ok && (e.l == p.t1 || e.l == p.t2):
+		p.move(k, p.t2)
+		return
+	case ok && (e.l == p.b1 || e.l == p.b2):
+		p.move(k, p.t2)
+	default:
+		p.entries[k] = arcEntry[K]{n: p.t1.pushBack(k), l: p.t1}
+	}
+
+	for p.t1.Len()+p.b1.Len() > p.capacity && p.b1.head != nil {
+		p.drop(p.b1.head.v)
+	}
+	for p.t1.Len()+p.t2.Len()+p.b1.Len()+p.b2.Len() > 2*p.capacity && p.b2.head != nil {
+		p.drop(p.b2.head.v)
+	}
+}
+
+func (p *arcPolicy[K]) Remove(k K) {
+	if _, ok := p.entries[k]; ok {
+		p.drop(k)
+	}
+}
+
+func (p *arcPolicy[K]) SetCapacity(capacity int) {
+	p.capacity = capacity
+}
+
+func (p *arcPolicy[K]) Victim(incoming K) (K, bool) {
+	if p.t1.head == nil && p.t2.head == nil {
+		var k K
+		return k, false
+	}
+
+	p.adapt(incoming)
+	e, ok := p.entries[incoming]
+	inB2 := ok && e.l == p.b2
+	var k K
+	if p.t1.head != nil && (p.t1.Len() > p.p || (inB2 && p.t1.Len() == p.p) || p.t2.head == nil) {
+		k = p.t1.head.v
+		p.move(k, p.b1)
+	} else {
+		k = p.t2.head.v
+		p.move(k, p.b2)
+	}
+	return k, true
+}
+
+// adapt moves the target size p towards the ghost list that holds the incoming key.
+// It does nothing if the key is not a ghost or if p was already adapted for it.
+func (p *arcPolicy[K]) adapt(incoming K) {
+	if p.adapted != nil && *p.adapted == incoming {
+		return
+	}
+	e, ok := p.entries[incoming]
+	switch {
+	case ok && e.l == p.b1:
+		p.p = min(p.capacity, p.p+max(p.b2.Len()/p.b1.Len(), 1))
+	case ok && e.l == p.b2:
+		p.p = max(0, p.p-max(p.b1.Len()/p.b2.Len(), 1))
+	default:
+		return
+	}
+	p.adapted = &incoming
+}
+
+// drop removes the key from whichever list it is on.
+func (p *arcPolicy[K]) drop(k K) {
+	e := p.entries[k]
+	e.l.unlink(e.n)
+	delete(p.entries, k)
+}
+
+// move moves the key to the back of the given list.
+func (p *arcPolicy[K]) move(k K, to *comfyList[K]) {
+	p.drop(k)
+	p.entries[k] = arcEntry[K]{n: to.pushBack(k), l: to}
+}
@@ -0,0 +1,199 @@
+package coll
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func evictionPolicyVictims(p EvictionPolicy[string], n int) []string {
+	victims := []string(nil)
+	for range n {
+		k, ok := p.Victim("")
+		if !ok {
+			break
+		}
+		victims = append(victims, k)
+	}
+	return victims
+}
+
+func Test_lruPolicy(t *testing.T) {
+	p := NewLRUPolicy[string]()
+	p.Insert("a")
+	p.Insert("b")
+	p.Insert("c")
+	p.Access("a")
+	p.Access("x")
+	p.Remove("b")
+
+	if got := evictionPolicyVictims(p, 5); !slices.Equal(got, []string{"c", "a"}) {
+		t.Errorf("Victim() order = %v, want %v", got, []string{"c", "a"})
+	}
+}
+
+func Test_lfuPolicy(t *testing.T) {
+	p := NewLFUPolicy[string]()
+	p.Insert("a")
+	p.Insert("b")
+	p.Insert("c")
+	p.Insert("d")
+	p.Access("a")
+	p.Access("a")
+	p.Access("b")
+	p.Access("c")
+
+	if k, _ := p.Victim(""); k != "d" {
+		t.Errorf("Victim() = %v, want d", k)
+	}
+	p.Remove("b")
+	if got := evictionPolicyVictims(p, 5); !slices.Equal(got, []string{"c", "a"}) {
+		t.Errorf("Victim() order = %v, want %v", got, []string{"c", "a"})
+	}
+
+	t.Run("Victim() after Remove() emptied the minimum bucket", func(t *testing.T) {
+		p := NewLFUPolicy[string]()
+		p.Insert("a")
+		p.Insert("b")
+		p.Access("b")
+		p.Access("b")
+		p.Insert("c")
+		p.Access("c")
+		p.Remove("a")
+		if k, _ := p.Victim(""); k != "c" {
+			t.Errorf("Victim() = %v, want c", k)
+		}
+	})
+}
+
+func Test_arcPolicy(t *testing.T) {
+	p := NewARCPolicy[string]().(*arcPolicy[string])
+	p.SetCapacity(2)
+
+	p.Insert("a")
+	p.Insert("b")
+	p.Access("a")
+
+	// "b" was seen once, so it is evicted before the frequently used "a".
+	if k, _ := p.Victim("c"); k != "b" {
+		t.Errorf("Victim() = %v, want b", k)
+	}
+	p.Insert("c")
+
+	// A ghost hit on "b" grows the recency target to 1 before the victim is chosen,
+	// so t1 holding just "c" is within its target and "a" is evicted from t2 instead.
+	if k, _ := p.Victim("b"); k != "a" {
+		t.Errorf("Victim() = %v, want a", k)
+	}
+	if p.p != 1 {
+		t.Errorf("p = %d, want 1 after a hit in b1", p.p)
+	}
+	p.Insert("b")
+	if p.p != 1 {
+		t.Errorf("p = %d, want 1, Insert() must not adapt it a second time", p.p)
+	}
+	if e := p.entries["b"]; e.l != p.t2 {
+		t.Error("ghost hit did not move the key to t2")
+	}
+}
+
+func Test_arcPolicy_ghostHitInB2(t *testing.T) {
+	p := NewARCPolicy[string]().(*arcPolicy[string])
+	p.SetCapacity(2)
+
+	p.Insert("a")
+	p.Access("a")
+	p.Insert("b")
+	p.Access("b")
+	if k, _ := p.Victim("x"); k != "a" {
+		t.Fatalf("Victim() = %v, want a", k)
+	}
+	p.Insert("c")
+	p.p = 2
+
+	// The ghost hit on "a" shrinks the recency target to 1, which t1 holding just "c" reaches,
+	// so "c" is evicted rather than "b" from t2.
+	if k, _ := p.Victim("a"); k != "c" {
+		t.Errorf("Victim() = %v, want c", k)
+	}
+	p.Insert("a")
+	if p.p != 1 {
+		t.Errorf("p = %d, want 1 after a hit in b2", p.p)
+	}
+
+	t.Run("Insert() without Victim() adapts the target", func(t *testing.T) {
+		p.Remove("b")
+		p.Insert("c")
+		if p.p != 2 {
+			t.Errorf("p = %d, want 2 after a hit in b1", p.p)
+		}
+	})
+}
+
+func Test_arcPolicy_invariants(t *testing.T) {
+	const capacity = 8
+	rnd := rand.New(rand.NewSource(42))
+	p := NewARCPolicy[int]().(*arcPolicy[int])
+	p.SetCapacity(capacity)
+	resident := map[int]bool{}
+
+	for range 5000 {
+		k := rnd.Intn(30)
+		if resident[k] {
+			p.Access(k)
+			continue
+		}
+		if len(resident) >= capacity {
+			victim, ok := p.Victim(k)
+			if !ok || !resident[victim] {
+				t.Fatalf("Victim() = %v, %v, which is not resident", victim, ok)
+			}
+			delete(resident, victim)
+		}
+		p.Insert(k)
+		resident[k] = true
+
+		if p.t1.Len()+p.t2.Len() != len(resident) {
+			t.Fatalf("t1 + t2 = %d, want %d", p.t1.Len()+p.t2.Len(), len(resident))
+		}
+		if p.t1.Len()+p.b1.Len() > capacity {
+			t.Fatalf("t1 + b1 = %d exceeds capacity", p.t1.Len()+p.b1.Len())
+		}
+		if total := p.t1.Len() + p.t2.Len() + p.b1.Len() + p.b2.Len(); total > 2*capacity {
+			t.Fatalf("directory size %d exceeds twice the capacity", total)
+		}
+		if p.p < 0 || p.p > capacity {
+			t.Fatalf("p = %d out of range", p.p)
+		}
+	}
+}
+
+func Test_evictionPolicy_Clone(t *testing.T) {
+	cases := []struct {
+		name   string
+		policy EvictionPolicy[string]
+	}{
+		{name: "LRU", policy: NewLRUPolicy[string]()},
+		{name: "LFU", policy: NewLFUPolicy[string]()},
+		{name: "ARC", policy: NewARCPolicy[string]()},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if ca, ok := tt.policy.(CapacityAware); ok {
+				ca.SetCapacity(3)
+			}
+			tt.policy.Insert("a")
+			tt.policy.Insert("b")
+			tt.policy.Access("a")
+			clone := tt.policy.Clone()
+
+			tt.policy.Clear()
+			if _, ok := tt.policy.Victim(""); ok {
+				t.Error("Victim() returned a key after Clear()")
+			}
+			if got := evictionPolicyVictims(clone, 5); !slices.Equal(got, []string{"b", "a"}) {
+				t.Errorf("Victim() order on clone = %v, want %v", got, []string{"b", "a"})
+			}
+		})
+	}
+}
@@ -0,0 +1,319 @@
+package coll
+
+import (
+	"iter"
+	"sync"
+	"time"
+)
+
+// Clock provides the current time. It allows replacing the system clock in tests.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// ExpiringMap is a map in which every entry carries a deadline after which it is considered expired.
+// Expired entries are hidden from all read methods and are physically removed by Sweep,
+// which can be run periodically with StartJanitor.
+//
+// Entries are kept in the order of their last write, so iteration and sweeping go from the oldest entry
+// to the newest one. They are kept in a linked list indexed by key, so that writes, reads and removals are O(1).
+// All methods are safe for concurrent use.
+// Yielded pairs are copies: changing them with SetVal does not modify the map. Use Set or SetWithTTL instead.
+type ExpiringMap[K comparable, V any] interface {
+	Ordered[Pair[K, V]]
+
+	// Clear removes all entries from the map.
+	Clear()
+
+	// Get returns the value associated with the given key if it has not expired.
+	Get(k K) (V, bool)
+
+	// GetOrDefault returns the value associated with the given key
+	// or the default value if the key is not found or has expired.
+	GetOrDefault(k K, defaultValue V) V
+
+	// Has returns true if the given key is present in the map and has not expired.
+	Has(k K) bool
+
+	// Keys returns an iterator over all keys that have not expired.
+	Keys() iter.Seq[K]
+
+	// KeyValues returns an iterator over all key-value pairs that have not expired.
+	KeyValues() iter.Seq2[K, V]
+
+	// Remove removes the entry associated with the given key.
+	Remove(k K)
+
+	// Set sets the value associated with the given key using the map's default TTL.
+	Set(k K, v V)
+
+	// SetWithTTL sets the value associated with the given key that expires after the given duration.
+	// A non-positive ttl means that the entry never expires.
+	SetWithTTL(k K, v V, ttl time.Duration)
+
+	// StartJanitor starts a goroutine that calls Sweep every interval.
+	// It panics if interval is not positive.
+	// Returns a function that stops the goroutine. It is safe to call the stop function more than once.
+	StartJanitor(interval time.Duration) (stop func())
+
+	// Sweep removes all expired entries.
+	// Returns the number of removed entries.
+	Sweep() (count int)
+}
+
+type comfyExpiringMap[K comparable, V any] struct {
+	mu        sync.Mutex
+	l         *comfyList[Pair[K, V]]
+	m         map[K]*comfyListNode[Pair[K, V]]
+	deadlines map[K]time.Time
+	ttl       time.Duration
+	clock     Clock
+}
+
+// NewExpiringMap creates a new ExpiringMap instance.
+// The ttl is used by Set, a non-positive ttl means that entries added with Set never expire.
+// If clock is nil, the system clock is used.
+func NewExpiringMap[K comparable, V any](ttl time.Duration, clock Clock) ExpiringMap[K, V] {
+	if clock == nil {
+		clock = systemClock{}
+	}
+	return &comfyExpiringMap[K, V]{
+		l:         &comfyList[Pair[K, V]]{},
+		m:         make(map[K]*comfyListNode[Pair[K, V]]),
+		deadlines: make(map[K]time.Time),
+		ttl:       ttl,
+		clock:     clock,
+	}
+}
+
+func (c *comfyExpiringMap[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.l.Clear()
+	c.m = make(map[K]*comfyListNode[Pair[K, V]])
+	c.deadlines = make(map[K]time.Time)
+}
+
+func (c *comfyExpiringMap[K, V]) Get(k K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.alive(k, c.clock.Now()) {
+		var v V
+		return v, false
+	}
+	return c.m[k].v.Val(), true
+}
+
+func (c *comfyExpiringMap[K, V]) GetOrDefault(k K, defaultValue V) V {
+	if v, ok := c.Get(k); ok {
+		return v
+	}
+	return defaultValue
+}
+
+func (c *comfyExpiringMap[K, V]) Has(k K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.alive(k, c.clock.Now())
+}
+
+func (c *comfyExpiringMap[K, V]) IsEmpty() bool {
+	return c.Len() == 0
+}
+
+func (c *comfyExpiringMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for _, pair := range c.snapshot() {
+			if !yield(pair.Key()) {
+				break
+			}
+		}
+	}
+}
+
+func (c *comfyExpiringMap[K, V]) KeyValues() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, pair := range c.snapshot() {
+			if !yield(pair.Key(), pair.Val()) {
+				break
+			}
+		}
+	}
+}
+
+// Len returns the number of entries that have not expired. It is O(n).
+func (c *comfyExpiringMap[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	count := 0
+	for pair := range c.l.Values() {
+		if c.alive(pair.Key(), now) {
+			count++
+		}
+	}
+	return count
+}
+
+func (c *comfyExpiringMap[K, V]) Remove(k K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.remove(k)
+}
+
+func (c *comfyExpiringMap[K, V]) Set(k K, v V) {
+	c.SetWithTTL(k, v, c.ttl)
+}
+
+func (c *comfyExpiringMap[K, V]) SetWithTTL(k K, v V, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Moving the key to the back keeps entries in the order of their last write.
+	if n, ok := c.m[k]; ok {
+		n.v = NewPair(k, v)
+		c.l.moveToBack(n)
+	} else {
+		c.m[k] = c.l.pushBack(NewPair(k, v))
+	}
+	if ttl > 0 {
+		c.deadlines[k] = c.clock.Now().Add(ttl)
+	} else {
+		delete(c.deadlines, k)
+	}
+}
+
+func (c *comfyExpiringMap[K, V]) StartJanitor(interval time.Duration) (stop func()) {
+	if interval <= 0 {
+		panic("StartJanitor() requires a positive interval")
+	}
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				c.Sweep()
+			case <-done:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+		})
+	}
+}
+
+func (c *comfyExpiringMap[K, V]) Sweep() (count int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	for n := c.l.head; n != nil; {
+		next := n.next
+		if !c.alive(n.v.Key(), now) {
+			c.remove(n.v.Key())
+			count++
+		}
+		n = next
+	}
+
+	return count
+}
+
+func (c *comfyExpiringMap[K, V]) Values() iter.Seq[Pair[K, V]] {
+	return func(yield func(Pair[K, V]) bool) {
+		for _, pair := range c.snapshot() {
+			if !yield(pair) {
+				break
+			}
+		}
+	}
+}
+
+func (c *comfyExpiringMap[K, V]) ValuesRev() iter.Seq[Pair[K, V]] {
+	return func(yield func(Pair[K, V]) bool) {
+		s := c.snapshot()
+		for i := len(s) - 1; i >= 0; i-- {
+			if !yield(s[i]) {
+				break
+			}
+		}
+	}
+}
+
+// Private:
+
+//nolint:unused
+func (c *comfyExpiringMap[K, V]) copy() baseInternal[Pair[K, V]] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	deadlines := make(map[K]time.Time, len(c.deadlines))
+	for k, d := range c.deadlines {
+		deadlines[k] = d
+	}
+	newEm := &comfyExpiringMap[K, V]{
+		l:         &comfyList[Pair[K, V]]{},
+		m:         make(map[K]*comfyListNode[Pair[K, V]], len(c.m)),
+		deadlines: deadlines,
+		ttl:       c.ttl,
+		clock:     c.clock,
+	}
+	for pair := range c.l.Values() {
+		newEm.m[pair.Key()] = newEm.l.pushBack(pair.copy())
+	}
+	return newEm
+}
+
+// alive returns true if the key is present and has not expired at the given time.
+// The caller must hold the lock.
+func (c *comfyExpiringMap[K, V]) alive(k K, now time.Time) bool {
+	if _, ok := c.m[k]; !ok {
+		return false
+	}
+	deadline, ok := c.deadlines[k]
+	return !ok || now.Before(deadline)
+}
+
+// remove removes the entry associated with the given key. The caller must hold the lock.
+func (c *comfyExpiringMap[K, V]) remove(k K) {
+	if n, ok := c.m[k]; ok {
+		c.l.unlink(n)
+		delete(c.m, k)
+	}
+	delete(c.deadlines, k)
+}
+
+// snapshot returns copies of the pairs that have not expired, so that they can be iterated over
+// without holding the lock.
+func (c *comfyExpiringMap[K, V]) snapshot() []Pair[K, V] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	s := make([]Pair[K, V], 0, c.l.Len())
+	for pair := range c.l.Values() {
+		if c.alive(pair.Key(), now) {
+			s = append(s, pair.copy())
+		}
+	}
+	return s
+}
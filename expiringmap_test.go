@@ -0,0 +1,265 @@
+package coll
+
+import (
+	"reflect"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newTestClock() *testClock {
+	return &testClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestNewExpiringMap(t *testing.T) {
+	m := NewExpiringMap[string, int](time.Minute, nil)
+	if m == nil {
+		t.Fatal("NewExpiringMap[string, int]() returned nil")
+	}
+	if _, ok := m.(*comfyExpiringMap[string, int]).clock.(systemClock); !ok {
+		t.Error("NewExpiringMap() with nil clock did not use the system clock")
+	}
+	m.Set("a", 1)
+	if v, ok := m.Get("a"); !ok || v != 1 {
+		t.Errorf("Get(a) = %v, %v, want 1, true", v, ok)
+	}
+}
+
+func Test_comfyExpiringMap_Get(t *testing.T) {
+	clock := newTestClock()
+	m := NewExpiringMap[string, int](10*time.Second, clock)
+	m.Set("a", 1)
+	m.SetWithTTL("b", 2, 30*time.Second)
+	m.SetWithTTL("c", 3, 0)
+
+	cases := []struct {
+		name    string
+		advance time.Duration
+		want    []string
+	}{
+		{name: "before any deadline", advance: 0, want: []string{"a", "b", "c"}},
+		{name: "just before default ttl", advance: 9 * time.Second, want: []string{"a", "b", "c"}},
+		{name: "at default ttl", advance: time.Second, want: []string{"b", "c"}},
+		{name: "after custom ttl", advance: 20 * time.Second, want: []string{"c"}},
+		{name: "much later", advance: 24 * time.Hour, want: []string{"c"}},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			clock.Advance(tt.advance)
+			if got := slices.Collect(m.Keys()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Keys() = %v, want %v", got, tt.want)
+			}
+			if m.Len() != len(tt.want) {
+				t.Errorf("Len() = %d, want %d", m.Len(), len(tt.want))
+			}
+			for _, k := range []string{"a", "b", "c"} {
+				visible := slices.Contains(tt.want, k)
+				if _, ok := m.Get(k); ok != visible {
+					t.Errorf("Get(%q) found = %v, want %v", k, ok, visible)
+				}
+				if m.Has(k) != visible {
+					t.Errorf("Has(%q) = %v, want %v", k, m.Has(k), visible)
+				}
+			}
+		})
+	}
+
+	if v := m.GetOrDefault("a", -1); v != -1 {
+		t.Errorf("GetOrDefault(a) = %v, want -1", v)
+	}
+	if v := m.GetOrDefault("c", -1); v != 3 {
+		t.Errorf("GetOrDefault(c) = %v, want 3", v)
+	}
+}
+
+func Test_comfyExpiringMap_Set_refreshes(t *testing.T) {
+	clock := newTestClock()
+	m := NewExpiringMap[string, int](10*time.Second, clock)
+	m.Set("a", 1)
+	m.Set("b", 2)
+
+	clock.Advance(5 * time.Second)
+	m.Set("a", 10)
+	clock.Advance(7 * time.Second)
+
+	if v, ok := m.Get("a"); !ok || v != 10 {
+		t.Errorf("Get(a) = %v, %v, want 10, true", v, ok)
+	}
+	if m.Has("b") {
+		t.Error("Has(b) = true after its deadline")
+	}
+
+	m.Set("b", 20)
+	kvs := [][2]any(nil)
+	for k, v := range m.KeyValues() {
+		kvs = append(kvs, [2]any{k, v})
+	}
+	if want := [][2]any{{"a", 10}, {"b", 20}}; !reflect.DeepEqual(kvs, want) {
+		t.Errorf("KeyValues() = %v, want %v", kvs, want)
+	}
+}
+
+func Test_comfyExpiringMap_Set_refreshesAll(t *testing.T) {
+	const size = 1000
+	m := NewExpiringMap[int, int](time.Minute, newTestClock())
+	for i := range size {
+		m.Set(i, i)
+	}
+	for i := size - 1; i >= 0; i-- {
+		m.Set(i, -i)
+	}
+
+	keys := slices.Collect(m.Keys())
+	if len(keys) != size || keys[0] != size-1 || keys[size-1] != 0 {
+		t.Errorf("refreshing did not move the entries to the back, first and last keys: %d, %d", keys[0], keys[size-1])
+	}
+	internal := m.(*comfyExpiringMap[int, int])
+	if len(internal.m) != size || internal.l.Len() != size {
+		t.Errorf("key index has %d keys, list has %d entries, want %d", len(internal.m), internal.l.Len(), size)
+	}
+}
+
+func Test_comfyExpiringMap_Sweep(t *testing.T) {
+	clock := newTestClock()
+	m := NewExpiringMap[int, int](0, clock)
+	for i := range 10 {
+		m.SetWithTTL(i, i, time.Duration(i+1)*time.Second)
+	}
+	m.Set(100, 100)
+
+	clock.Advance(5 * time.Second)
+	if count := m.Sweep(); count != 5 {
+		t.Errorf("Sweep() = %d, want 5", count)
+	}
+	if count := m.Sweep(); count != 0 {
+		t.Errorf("second Sweep() = %d, want 0", count)
+	}
+	internal := m.(*comfyExpiringMap[int, int])
+	if internal.l.Len() != 6 || len(internal.deadlines) != 5 {
+		t.Errorf("Sweep() left %d entries and %d deadlines, want 6 and 5", internal.l.Len(), len(internal.deadlines))
+	}
+
+	keys := []int(nil)
+	for p := range m.ValuesRev() {
+		keys = append(keys, p.Key())
+	}
+	if want := []int{100, 9, 8, 7, 6, 5}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("ValuesRev() keys = %v, want %v", keys, want)
+	}
+}
+
+func Test_comfyExpiringMap_RemoveClear(t *testing.T) {
+	m := NewExpiringMap[string, int](time.Minute, newTestClock())
+	m.Set("a", 1)
+	m.Set("b", 2)
+
+	m.Remove("a")
+	m.Remove("x")
+	if m.Has("a") || m.Len() != 1 {
+		t.Errorf("Remove() resulted in Len() = %d", m.Len())
+	}
+	m.Clear()
+	if !m.IsEmpty() {
+		t.Error("Clear() did not remove all entries")
+	}
+}
+
+func Test_comfyExpiringMap_StartJanitor(t *testing.T) {
+	clock := newTestClock()
+	m := NewExpiringMap[string, int](time.Second, clock)
+	m.Set("a", 1)
+	clock.Advance(time.Minute)
+
+	stop := m.StartJanitor(time.Millisecond)
+	defer stop()
+
+	internal := m.(*comfyExpiringMap[string, int])
+	deadline := time.Now().Add(time.Second)
+	for {
+		internal.mu.Lock()
+		n := internal.l.Len()
+		internal.mu.Unlock()
+		if n == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("janitor did not sweep expired entries")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	stop()
+	stop()
+
+	t.Run("StartJanitor() with non-positive interval panics", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r != "StartJanitor() requires a positive interval" {
+				t.Errorf("StartJanitor(0) panicked with wrong error: %v", r)
+			}
+		}()
+		m.StartJanitor(0)
+	})
+}
+
+func Test_comfyExpiringMap_yieldedPairsAreCopies(t *testing.T) {
+	m := NewExpiringMap[string, int](time.Minute, newTestClock())
+	m.Set("a", 1)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for range 100 {
+			m.Get("a")
+		}
+	}()
+	for range 100 {
+		for p := range m.Values() {
+			p.SetVal(-1)
+		}
+		for p := range m.ValuesRev() {
+			p.SetVal(-1)
+		}
+	}
+	wg.Wait()
+
+	if v, _ := m.Get("a"); v != 1 {
+		t.Errorf("changing a yielded pair changed the map: Get(a) = %d", v)
+	}
+}
+
+func Test_comfyExpiringMap_copy(t *testing.T) {
+	clock := newTestClock()
+	c1 := NewExpiringMap[string, int](time.Minute, clock)
+	c1.Set("a", 1)
+	c2 := Copy(c1)
+
+	c1.Set("b", 2)
+	c1.Remove("a")
+	if v, ok := c2.Get("a"); !ok || v != 1 || c2.Has("b") {
+		t.Errorf("copy() did not create a deep copy, Get(a) = %v, %v", v, ok)
+	}
+
+	clock.Advance(2 * time.Minute)
+	if c2.Has("a") {
+		t.Error("copy() did not keep the deadlines")
+	}
+}
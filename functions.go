@@ -1,7 +1,51 @@
 package coll
 
+import (
+	"iter"
+	"slices"
+)
+
+// KeyCollisionPolicy defines what happens when a transformation maps several keys to the same key.
+type KeyCollisionPolicy int
+
+const (
+	// KeyCollisionKeepFirst keeps the value of the first colliding key.
+	KeyCollisionKeepFirst KeyCollisionPolicy = iota
+
+	// KeyCollisionKeepLast keeps the value of the last colliding key.
+	KeyCollisionKeepLast
+
+	// KeyCollisionError makes the transformation fail with ErrDuplicateKey.
+	KeyCollisionError
+)
+
 // Public:
 
+// Chunk returns an iterator over consecutive chunks of the given collection, each with size elements.
+// The last chunk holds the remaining elements and may be smaller.
+// Chunks are created lazily and are independent of the collection.
+// It panics if size is less than 1.
+func Chunk[V any](coll Ordered[V], size int) iter.Seq[Sequence[V]] {
+	if size < 1 {
+		panic("Chunk() requires a size of at least 1")
+	}
+	return func(yield func(Sequence[V]) bool) {
+		chunk := make([]V, 0, size)
+		for v := range coll.Values() {
+			chunk = append(chunk, v)
+			if len(chunk) == size {
+				if !yield(NewSequenceFrom(chunk)) {
+					return
+				}
+				chunk = make([]V, 0, size)
+			}
+		}
+		if len(chunk) > 0 {
+			yield(NewSequenceFrom(chunk))
+		}
+	}
+}
+
 // Copy creates a copy of the given collection.
 func Copy[C Base[V], V any](coll C) C {
 	// check if c is of type baseInternal[T]:
@@ -11,56 +55,262 @@ func Copy[C Base[V], V any](coll C) C {
 	panic("Copy() requires a collection that implements the baseInternal interface")
 }
 
-//// Filter creates a new, filtered collection from the given collection.
-//func Filter[C Indexed[V], V any](c C, predicate func(int, V) bool) C {
-//	panic("not implemented")
-//}
-//
-//// MapTo creates a new, mapped collection from the given collection.
-// Maybe this should be called "Transform"? Maybe "MapTo" should be an alias for "Transform"?
-//func MapTo[OUT Indexed[N], IN Indexed[V], V, N any](coll IN, transformer func(int, V) N) OUT {
-//	panic("not implemented")
-//}
-//
-//// Remap creates a new, mapped collection from the given collection.
-//func Remap[C Indexed[V], V any](coll C) C {
-//	panic("not implemented")
-//}
-//
-//// Reverse creates a new, reversed collection from the given collection.
-//func Reverse[C Indexed[V], V any](coll C) C {
-//	panic("not implemented")
-//}
-//
-//// Sort creates a new, sorted collection from the given collection.
-//func Sort[C Indexed[V], V any](coll C, cmp func(a, b V) int) C {
-//	panic("not implemented")
-//}
-
-// private API:
-
-//func MapToSameColl[N any, V any, OUT Indexed[N], IN Indexed[V]](coll IN, transformer func(int, V) V) OUT {
-//	panic("not implemented")
-//}
-
-//func Transform[IN Indexed[V], V, N any](coll C, transformer func(int, V) C) C {
-//	panic("not implemented")
-//}
-
-//func Filter[T Indexed[V], V any](c T, predicate func(V) bool) T {
-//	// check if c is of type Sequence[T]:
-//	if _, ok := any(c).(Sequence[V]); ok {
-//		return c.FindAll(predicate).(*comfySeq[V])
-//	}
-//
-//	// check if c is of type CmpSequence[T]:
-//	if _, ok := any(c).(CmpSequence[V]); ok {
-//		return c.FindAll(predicate).(*comfyCmpSeq[V])
-//	}
-//
-//	// check if c is of type Map[K, T]:
-//}
-//
-//func Sort[T Indexed[V], V any](c T, cmp func(a, b V) int) T {
-//
-//}
+// CountBy counts the elements of the given collection by the key returned by keyFn.
+// Keys are ordered by their first occurrence.
+func CountBy[V any, K comparable](coll Base[V], keyFn func(V) K) CmpMap[K, int] {
+	counts := NewCmpMap[K, int]()
+	for v := range coll.Values() {
+		k := keyFn(v)
+		counts.Set(k, counts.GetOrDefault(k, 0)+1)
+	}
+	return counts
+}
+
+// Filter creates a new collection of the same kind with the elements of the given collection
+// that match the predicate. The given collection is not modified.
+func Filter[C Mutable[V], V any](coll C, predicate Predicate[V]) C {
+	if c, ok := any(coll).(filterInternal[V]); ok {
+		return c.filter(predicate).(C)
+	}
+	if c, ok := any(coll).(mutableInternal[V]); ok {
+		filtered := c.copy().(mutableInternal[V])
+		filtered.RemoveMatching(func(v V) bool {
+			return !predicate(v)
+		})
+		return filtered.(C)
+	}
+	panic("Filter() requires a collection that implements the mutableInternal interface")
+}
+
+// FlatMap creates a new Sequence with all elements yielded by the given function for each element
+// of the given collection, in order.
+func FlatMap[V, N any](coll Base[V], transformer func(V) iter.Seq[N]) Sequence[N] {
+	s := []N(nil)
+	for v := range coll.Values() {
+		for n := range transformer(v) {
+			s = append(s, n)
+		}
+	}
+	return NewSequenceFrom(s)
+}
+
+// Fold reduces the given collection to a single value, starting with the initial value
+// and combining it with each element in order.
+func Fold[V, A any](coll Base[V], initial A, f func(acc A, v V) A) A {
+	acc := initial
+	for v := range coll.Values() {
+		acc = f(acc, v)
+	}
+	return acc
+}
+
+// FoldRight is like Fold, but combines the elements in reverse order, from the last to the first.
+func FoldRight[V, A any](coll Ordered[V], initial A, f func(acc A, v V) A) A {
+	acc := initial
+	for v := range coll.ValuesRev() {
+		acc = f(acc, v)
+	}
+	return acc
+}
+
+// GroupBy groups the elements of the given collection by the key returned by keyFn.
+// Keys are ordered by their first occurrence, and elements within each group keep their original order.
+func GroupBy[V any, K comparable](coll Base[V], keyFn func(V) K) Map[K, Sequence[V]] {
+	groups := NewMap[K, Sequence[V]]().(*comfyMap[K, Sequence[V]])
+	for v := range coll.Values() {
+		k := keyFn(v)
+		if group, ok := groups.m[k]; ok {
+			group.Val().Append(v)
+			continue
+		}
+		groups.set(NewPair(k, NewSequenceFrom([]V{v})))
+	}
+	return groups
+}
+
+// MapKeys creates a new Map with the keys of the given map transformed by the given function,
+// keeping the order of pairs. Values are not copied.
+// When several keys are transformed into the same key, the collision policy decides which value is kept;
+// the pair keeps the position of the first colliding key.
+// With KeyCollisionError, ErrDuplicateKey is returned together with a nil map.
+func MapKeys[K, J comparable, V any](
+	m Map[K, V],
+	transformer func(K) J,
+	policy KeyCollisionPolicy,
+) (Map[J, V], error) {
+	mapped := NewMap[J, V]().(*comfyMap[J, V])
+	for k, v := range m.KeyValues() {
+		j := transformer(k)
+		if mapped.Has(j) {
+			switch policy {
+			case KeyCollisionKeepFirst:
+				continue
+			case KeyCollisionError:
+				return nil, ErrDuplicateKey
+			}
+		}
+		mapped.set(NewPair(j, v))
+	}
+	return mapped, nil
+}
+
+// MapTo creates a new Sequence with the elements of the given collection transformed by the given function.
+// It is an alias for Transform.
+func MapTo[V, N any](coll Base[V], transformer func(V) N) Sequence[N] {
+	return Transform(coll, transformer)
+}
+
+// MapValues creates a new Map with the values of the given map transformed by the given function,
+// keeping the keys and their order.
+func MapValues[K comparable, V, N any](m Map[K, V], transformer func(V) N) Map[K, N] {
+	mapped := NewMap[K, N]().(*comfyMap[K, N])
+	for k, v := range m.KeyValues() {
+		mapped.set(NewPair(k, transformer(v)))
+	}
+	return mapped
+}
+
+// Pairwise returns an iterator over all pairs of consecutive elements of the given collection.
+// Collections with fewer than two elements yield nothing.
+func Pairwise[V any](coll Ordered[V]) iter.Seq2[V, V] {
+	return func(yield func(V, V) bool) {
+		var prev V
+		first := true
+		for v := range coll.Values() {
+			if !first && !yield(prev, v) {
+				return
+			}
+			prev = v
+			first = false
+		}
+	}
+}
+
+// Partition splits the elements of the given collection into those that match the predicate and the rest,
+// keeping their original order.
+func Partition[V any](coll Base[V], predicate Predicate[V]) (matching, rest Sequence[V]) {
+	matching = NewSequence[V]()
+	rest = NewSequence[V]()
+	for v := range coll.Values() {
+		if predicate(v) {
+			matching.Append(v)
+		} else {
+			rest.Append(v)
+		}
+	}
+	return matching, rest
+}
+
+// Reduce reduces the given collection to a single value, combining the elements in order
+// and using the first element as the initial value.
+// Returns ErrEmptyCollection if the collection is empty.
+func Reduce[V any](coll Base[V], reducer func(acc, v V) V) (V, error) {
+	var acc V
+	first := true
+	for v := range coll.Values() {
+		if first {
+			acc = v
+			first = false
+			continue
+		}
+		acc = reducer(acc, v)
+	}
+	if first {
+		return acc, ErrEmptyCollection
+	}
+	return acc, nil
+}
+
+// Remap creates a new collection of the same kind with the elements of the given collection
+// mapped by the given function. The given collection is not modified.
+func Remap[C Mutable[V], V any](coll C, mapper Mapper[V]) C {
+	if c, ok := any(coll).(remapInternal[V]); ok {
+		return c.remap(mapper).(C)
+	}
+	if c, ok := any(coll).(mutableInternal[V]); ok {
+		mapped := c.copy().(mutableInternal[V])
+		mapped.Apply(mapper)
+		return mapped.(C)
+	}
+	panic("Remap() requires a collection that implements the mutableInternal interface")
+}
+
+// Reverse creates a new collection of the same kind with the elements of the given collection in reverse order.
+// The given collection is not modified.
+func Reverse[C OrderedMutable[V], V any](coll C) C {
+	reversed := Copy(coll)
+	reversed.Reverse()
+	return reversed
+}
+
+// Scan is like Fold, but returns a Sequence with all intermediate results:
+// the n-th element of the result is the accumulated value after combining the first n+1 elements.
+// The initial value itself is not included.
+func Scan[V, A any](coll Base[V], initial A, f func(acc A, v V) A) Sequence[A] {
+	s := []A(nil)
+	if coll.Len() > 0 {
+		s = make([]A, 0, coll.Len())
+	}
+	acc := initial
+	for v := range coll.Values() {
+		acc = f(acc, v)
+		s = append(s, acc)
+	}
+	return NewSequenceFrom(s)
+}
+
+// Sort creates a new collection of the same kind with the elements of the given collection
+// sorted using the given comparator. The given collection is not modified.
+func Sort[C sortable[V], V any](coll C, cmp Comparator[V]) C {
+	sorted := Copy(coll)
+	sorted.Sort(cmp)
+	return sorted
+}
+
+// Transform creates a new Sequence with the elements of the given collection transformed by the given function.
+// Unlike Remap, it can change the type of elements, so the result is always a Sequence.
+func Transform[V, N any](coll Base[V], transformer func(V) N) Sequence[N] {
+	s := []N(nil)
+	if coll.Len() > 0 {
+		s = make([]N, 0, coll.Len())
+	}
+	for v := range coll.Values() {
+		s = append(s, transformer(v))
+	}
+	return NewSequenceFrom(s)
+}
+
+// Window returns an iterator over sliding windows of the given collection, each with size elements.
+// Each window starts step elements after the previous one, so windows overlap when step is less than size
+// and elements are skipped when step is greater than size. Trailing elements that do not fill a whole window
+// are not yielded.
+// Windows are created lazily and are independent of the collection.
+// It panics if size or step is less than 1.
+func Window[V any](coll Ordered[V], size, step int) iter.Seq[Sequence[V]] {
+	if size < 1 || step < 1 {
+		panic("Window() requires a size and a step of at least 1")
+	}
+	return func(yield func(Sequence[V]) bool) {
+		buf := make([]V, 0, size)
+		skip := 0
+		for v := range coll.Values() {
+			if skip > 0 {
+				skip--
+				continue
+			}
+			buf = append(buf, v)
+			if len(buf) < size {
+				continue
+			}
+			if !yield(NewSequenceFrom(slices.Clone(buf))) {
+				return
+			}
+			if step >= size {
+				buf = buf[:0]
+				skip = step - size
+			} else {
+				buf = buf[:copy(buf, buf[step:])]
+			}
+		}
+	}
+}
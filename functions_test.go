@@ -1,8 +1,13 @@
 package coll
 
 import (
+	"cmp"
+	"errors"
 	"iter"
 	"reflect"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -28,6 +33,26 @@ func (*baseFakeWithoutInternal[V]) Values() iter.Seq[V] {
 	return nil
 }
 
+func boundedMapFrom(s []Pair[int, int]) BoundedMap[int, int] {
+	m := NewBoundedMap[int, int](10, NewLRUPolicy[int]())
+	m.SetMany(s)
+	return m
+}
+
+type mutableFakeWithoutInternal[V any] struct {
+	baseFakeWithoutInternal[V]
+}
+
+func (*mutableFakeWithoutInternal[V]) Apply(_ Mapper[V]) {
+}
+
+func (*mutableFakeWithoutInternal[V]) Clear() {
+}
+
+func (*mutableFakeWithoutInternal[V]) RemoveMatching(_ Predicate[V]) int {
+	return 0
+}
+
 func Test_Copy_forEachFlatCollection(t *testing.T) {
 	cases := []struct {
 		name string
@@ -49,6 +74,30 @@ func Test_Copy_forEachFlatCollection(t *testing.T) {
 			name: "Copy() three-item CmpSequence",
 			coll: NewCmpSequenceFrom([]int{111, 222, 333}),
 		},
+		{
+			name: "Copy() on empty List",
+			coll: NewList[int](),
+		},
+		{
+			name: "Copy() three-item List",
+			coll: NewListFrom([]int{111, 222, 333}),
+		},
+		{
+			name: "Copy() on empty CmpList",
+			coll: NewCmpList[int](),
+		},
+		{
+			name: "Copy() three-item CmpList",
+			coll: NewCmpListFrom([]int{111, 222, 333}),
+		},
+		{
+			name: "Copy() on empty Deque",
+			coll: NewDeque[int](),
+		},
+		{
+			name: "Copy() three-item Deque",
+			coll: NewDequeFrom([]int{111, 222, 333}),
+		},
 	}
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
@@ -101,6 +150,18 @@ func Test_Copy_forEachMap(t *testing.T) {
 				NewPair(3, 333),
 			}),
 		},
+		{
+			name: "Copy() on empty SortedMap",
+			coll: NewSortedMap[int, int](),
+		},
+		{
+			name: "Copy() three-item SortedMap",
+			coll: NewSortedMapFrom([]Pair[int, int]{
+				NewPair(3, 333),
+				NewPair(1, 111),
+				NewPair(2, 222),
+			}),
+		},
 	}
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
@@ -139,3 +200,652 @@ func Test_Copy_ofCollectionWithoutInternal(t *testing.T) {
 		Copy(coll)
 	})
 }
+
+func Test_Filter_forEachFlatCollection(t *testing.T) {
+	cases := []struct {
+		name string
+		coll OrderedMutable[int]
+	}{
+		{name: "Filter() on Sequence", coll: NewSequenceFrom([]int{1, 2, 3, 4, 5})},
+		{name: "Filter() on CmpSequence", coll: NewCmpSequenceFrom([]int{1, 2, 3, 4, 5})},
+		{name: "Filter() on List", coll: NewListFrom([]int{1, 2, 3, 4, 5})},
+		{name: "Filter() on CmpList", coll: NewCmpListFrom([]int{1, 2, 3, 4, 5})},
+		{name: "Filter() on Deque", coll: NewDequeFrom([]int{1, 2, 3, 4, 5})},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			want := Copy(tt.coll)
+			want.RemoveMatching(func(v int) bool { return v%2 == 0 })
+
+			got := Filter(tt.coll, func(v int) bool { return v%2 == 1 })
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Filter() = %v, want %v", got, want)
+			}
+			if tt.coll.Len() != 5 {
+				t.Errorf("Filter() modified the given collection")
+			}
+		})
+	}
+}
+
+func Test_Filter_forEachMap(t *testing.T) {
+	pairs := func() []Pair[int, int] {
+		return []Pair[int, int]{NewPair(3, 333), NewPair(1, 111), NewPair(2, 222)}
+	}
+	cases := []struct {
+		name string
+		coll Map[int, int]
+	}{
+		{name: "Filter() on Map", coll: NewMapFrom(pairs())},
+		{name: "Filter() on CmpMap", coll: NewCmpMapFrom(pairs())},
+		{name: "Filter() on SortedMap", coll: NewSortedMapFrom(pairs())},
+		{name: "Filter() on BiMap", coll: NewBiMapFrom(pairs(), DuplicateValueReject)},
+		{name: "Filter() on BoundedMap", coll: boundedMapFrom(pairs())},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			want := Copy(tt.coll)
+			want.Remove(2)
+
+			got := Filter(tt.coll, func(p Pair[int, int]) bool { return p.Key() != 2 })
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Filter() = %v, want %v", got, want)
+			}
+
+			p, _ := got.At(0)
+			p.SetVal(-1)
+			if tt.coll.Len() != 3 || tt.coll.GetOrDefault(p.Key(), 0) == -1 {
+				t.Errorf("Filter() result shares pairs with the given collection")
+			}
+		})
+	}
+}
+
+func Test_Filter_forSets(t *testing.T) {
+	set := NewSetFrom([]int{5, 1, 4, 2})
+	if got := slices.Collect(Filter(set, func(v int) bool { return v > 1 }).Values()); !reflect.DeepEqual(got, []int{5, 4, 2}) {
+		t.Errorf("Filter() on Set = %v", got)
+	}
+
+	sortedSet := NewSortedSetFrom([]int{5, 1, 4, 2})
+	filtered := Filter(sortedSet, func(v int) bool { return v > 1 })
+	if !reflect.DeepEqual(filtered, NewSortedSetFrom([]int{2, 4, 5})) {
+		t.Errorf("Filter() on SortedSet = %v", slices.Collect(filtered.Values()))
+	}
+	if sortedSet.Len() != 4 {
+		t.Errorf("Filter() modified the given collection")
+	}
+}
+
+func Test_FilterAndRemap_forBitSetAndTrie(t *testing.T) {
+	bs := NewBitSetFrom([]int{1, 4, 9, 16})
+	if got := slices.Collect(Filter(bs, func(v int) bool { return v%2 == 0 }).Values()); !reflect.DeepEqual(got, []int{4, 16}) {
+		t.Errorf("Filter() on BitSet = %v", got)
+	}
+	if got := slices.Collect(Remap(bs, func(v int) int { return v + 1 }).Values()); !reflect.DeepEqual(got, []int{2, 5, 10, 17}) {
+		t.Errorf("Remap() on BitSet = %v", got)
+	}
+	if bs.Len() != 4 {
+		t.Error("Filter() or Remap() modified the given collection")
+	}
+
+	tr := NewTrieFrom([]Pair[string, int]{NewPair("a", 1), NewPair("ab", 2), NewPair("b", 3)})
+	filtered := Filter(tr, func(p Pair[string, int]) bool { return p.Val() > 1 })
+	if got := slices.Collect(filtered.Keys()); !reflect.DeepEqual(got, []string{"ab", "b"}) {
+		t.Errorf("Filter() on Trie = %v", got)
+	}
+	remapped := Remap(tr, func(p Pair[string, int]) Pair[string, int] { return NewPair(p.Key(), p.Val()*10) })
+	if v, _ := remapped.Get("ab"); v != 20 {
+		t.Errorf("Remap() on Trie Get(ab) = %d, want 20", v)
+	}
+	if v, _ := tr.Get("ab"); v != 2 || tr.Len() != 3 {
+		t.Error("Filter() or Remap() modified the given collection")
+	}
+}
+
+func Test_Filter_ofCollectionWithoutInternal(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "Filter() requires a collection that implements the mutableInternal interface" {
+			t.Errorf("Filter() panicked with wrong error: %v", r)
+		}
+	}()
+	Filter[*mutableFakeWithoutInternal[int]](&mutableFakeWithoutInternal[int]{}, func(int) bool { return true })
+}
+
+func Test_Remap_forEachFlatCollection(t *testing.T) {
+	cases := []struct {
+		name string
+		coll OrderedMutable[int]
+	}{
+		{name: "Remap() on Sequence", coll: NewSequenceFrom([]int{1, 2, 3})},
+		{name: "Remap() on CmpSequence", coll: NewCmpSequenceFrom([]int{1, 2, 3})},
+		{name: "Remap() on List", coll: NewListFrom([]int{1, 2, 3})},
+		{name: "Remap() on CmpList", coll: NewCmpListFrom([]int{1, 2, 3})},
+		{name: "Remap() on Deque", coll: NewDequeFrom([]int{1, 2, 3})},
+		{name: "Remap() on empty Sequence", coll: NewSequence[int]()},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			want := Copy(tt.coll)
+			want.Apply(func(v int) int { return v * 10 })
+			original := Copy(tt.coll)
+
+			got := Remap(tt.coll, func(v int) int { return v * 10 })
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Remap() = %v, want %v", got, want)
+			}
+			if !reflect.DeepEqual(tt.coll, original) {
+				t.Errorf("Remap() modified the given collection")
+			}
+		})
+	}
+}
+
+func Test_Remap_forEachMap(t *testing.T) {
+	pairs := func() []Pair[int, int] {
+		return []Pair[int, int]{NewPair(3, 333), NewPair(1, 111), NewPair(2, 222)}
+	}
+	mapper := func(p Pair[int, int]) Pair[int, int] {
+		p.SetVal(p.Val() + 1)
+		return p
+	}
+	cases := []struct {
+		name string
+		coll Map[int, int]
+	}{
+		{name: "Remap() on Map", coll: NewMapFrom(pairs())},
+		{name: "Remap() on CmpMap", coll: NewCmpMapFrom(pairs())},
+		{name: "Remap() on SortedMap", coll: NewSortedMapFrom(pairs())},
+		{name: "Remap() on BiMap", coll: NewBiMapFrom(pairs(), DuplicateValueReject)},
+		{name: "Remap() on BoundedMap", coll: boundedMapFrom(pairs())},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			want := Copy(tt.coll)
+			want.Apply(mapper)
+			original := Copy(tt.coll)
+
+			got := Remap(tt.coll, mapper)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Remap() = %v, want %v", got, want)
+			}
+			if !reflect.DeepEqual(tt.coll, original) {
+				t.Errorf("Remap() modified the given collection")
+			}
+		})
+	}
+
+	t.Run("Remap() on CmpMap keeps the value counter", func(t *testing.T) {
+		got := Remap(NewCmpMapFrom(pairs()), func(p Pair[int, int]) Pair[int, int] {
+			return NewPair(p.Key(), 0)
+		})
+		if count := got.CountValues(0); count != 3 {
+			t.Errorf("CountValues(0) = %d, want 3", count)
+		}
+	})
+}
+
+func Test_Remap_forSet(t *testing.T) {
+	got := Remap(NewSetFrom([]int{1, 2, 3, 4}), func(v int) int { return v / 2 })
+	if !reflect.DeepEqual(got, NewSetFrom([]int{0, 1, 2})) {
+		t.Errorf("Remap() on Set = %v", slices.Collect(got.Values()))
+	}
+}
+
+func Test_Remap_ofCollectionWithoutInternal(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "Remap() requires a collection that implements the mutableInternal interface" {
+			t.Errorf("Remap() panicked with wrong error: %v", r)
+		}
+	}()
+	Remap[*mutableFakeWithoutInternal[int]](&mutableFakeWithoutInternal[int]{}, func(v int) int { return v })
+}
+
+func Test_MapTo(t *testing.T) {
+	got := MapTo(NewListFrom([]int{1, 22, 333}), func(v int) string { return strconv.Itoa(v) })
+	if !reflect.DeepEqual(got, NewSequenceFrom([]string{"1", "22", "333"})) {
+		t.Errorf("MapTo() = %v", got)
+	}
+	if got := MapTo(NewSequence[int](), strconv.Itoa); !reflect.DeepEqual(got, NewSequence[string]()) {
+		t.Errorf("MapTo() on empty collection = %v", got)
+	}
+}
+
+func Test_ReverseAndSort(t *testing.T) {
+	cases := []struct {
+		name string
+		coll sortable[int]
+	}{
+		{name: "Sequence", coll: NewSequenceFrom([]int{2, 3, 1})},
+		{name: "CmpSequence", coll: NewCmpSequenceFrom([]int{2, 3, 1})},
+		{name: "List", coll: NewListFrom([]int{2, 3, 1})},
+		{name: "CmpList", coll: NewCmpListFrom([]int{2, 3, 1})},
+		{name: "Deque", coll: NewDequeFrom([]int{2, 3, 1})},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := slices.Collect(Reverse(tt.coll).Values()); !reflect.DeepEqual(got, []int{1, 3, 2}) {
+				t.Errorf("Reverse() = %v", got)
+			}
+			if got := slices.Collect(Sort(tt.coll, cmp.Compare[int]).Values()); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+				t.Errorf("Sort() = %v", got)
+			}
+			if got := slices.Collect(tt.coll.Values()); !reflect.DeepEqual(got, []int{2, 3, 1}) {
+				t.Errorf("Reverse() or Sort() modified the given collection: %v", got)
+			}
+		})
+	}
+
+	t.Run("Map", func(t *testing.T) {
+		m := NewMapFrom([]Pair[int, int]{NewPair(2, 0), NewPair(3, 0), NewPair(1, 0)})
+		if got := slices.Collect(Reverse(m).Keys()); !reflect.DeepEqual(got, []int{1, 3, 2}) {
+			t.Errorf("Reverse() = %v", got)
+		}
+		sorted := Sort(m, func(a, b Pair[int, int]) int { return a.Key() - b.Key() })
+		if got := slices.Collect(sorted.Keys()); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+			t.Errorf("Sort() = %v", got)
+		}
+		if got := slices.Collect(m.Keys()); !reflect.DeepEqual(got, []int{2, 3, 1}) {
+			t.Errorf("Reverse() or Sort() modified the given collection: %v", got)
+		}
+	})
+}
+
+func Test_Transform(t *testing.T) {
+	cases := []struct {
+		name string
+		coll Base[int]
+		want []string
+	}{
+		{name: "Transform() on Sequence", coll: NewSequenceFrom([]int{1, 22, 333}), want: []string{"1", "22", "333"}},
+		{name: "Transform() on SortedSet", coll: NewSortedSetFrom([]int{3, 1, 2}), want: []string{"1", "2", "3"}},
+		{name: "Transform() on empty List", coll: NewList[int](), want: []string(nil)},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Transform(tt.coll, strconv.Itoa)
+			if !reflect.DeepEqual(got, NewSequenceFrom(tt.want)) {
+				t.Errorf("Transform() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_MapValues(t *testing.T) {
+	m := NewMapFrom([]Pair[string, int]{NewPair("b", 2), NewPair("a", 1), NewPair("c", 3)})
+	got := MapValues(m, func(v int) string { return strings.Repeat("x", v) })
+
+	want := NewMapFrom([]Pair[string, string]{NewPair("b", "xx"), NewPair("a", "x"), NewPair("c", "xxx")})
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MapValues() = %v, want %v", got, want)
+	}
+	if got := MapValues(NewMap[string, int](), strconv.Itoa); !got.IsEmpty() {
+		t.Errorf("MapValues() on empty map = %v", got)
+	}
+}
+
+func Test_MapKeys(t *testing.T) {
+	pairs := func() []Pair[string, int] {
+		return []Pair[string, int]{NewPair("b", 1), NewPair("A", 2), NewPair("a", 3), NewPair("B", 4), NewPair("c", 5)}
+	}
+	cases := []struct {
+		name    string
+		policy  KeyCollisionPolicy
+		want    [][2]any
+		wantErr error
+	}{
+		{
+			name:   "MapKeys() keeping first values",
+			policy: KeyCollisionKeepFirst,
+			want:   [][2]any{{"B", 1}, {"A", 2}, {"C", 5}},
+		},
+		{
+			name:   "MapKeys() keeping last values",
+			policy: KeyCollisionKeepLast,
+			want:   [][2]any{{"B", 4}, {"A", 3}, {"C", 5}},
+		},
+		{
+			name:    "MapKeys() failing on collision",
+			policy:  KeyCollisionError,
+			wantErr: ErrDuplicateKey,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MapKeys(NewMapFrom(pairs()), strings.ToUpper, tt.policy)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("MapKeys() returned error %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if got != nil {
+					t.Errorf("MapKeys() returned %v together with an error", got)
+				}
+				return
+			}
+			kvs := [][2]any(nil)
+			for k, v := range got.KeyValues() {
+				kvs = append(kvs, [2]any{k, v})
+			}
+			if !reflect.DeepEqual(kvs, tt.want) {
+				t.Errorf("MapKeys() = %v, want %v", kvs, tt.want)
+			}
+		})
+	}
+
+	t.Run("MapKeys() without collisions", func(t *testing.T) {
+		got, err := MapKeys(NewMapFrom(pairs()), func(k string) string { return k + k }, KeyCollisionError)
+		if err != nil || got.Len() != 5 {
+			t.Errorf("MapKeys() = %v, %v", got, err)
+		}
+	})
+}
+
+func Test_FlatMap(t *testing.T) {
+	coll := NewSequenceFrom([]int{0, 1, 2, 3})
+	got := FlatMap(coll, func(v int) iter.Seq[string] {
+		return slices.Values(slices.Repeat([]string{strconv.Itoa(v)}, v))
+	})
+	if !reflect.DeepEqual(got, NewSequenceFrom([]string{"1", "2", "2", "3", "3", "3"})) {
+		t.Errorf("FlatMap() = %v", got)
+	}
+
+	nested := NewListFrom([]Sequence[int]{NewSequenceFrom([]int{1, 2}), NewSequence[int](), NewSequenceFrom([]int{3})})
+	flattened := FlatMap(nested, Sequence[int].Values)
+	if got := slices.Collect(flattened.Values()); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Errorf("FlatMap() of nested sequences = %v", got)
+	}
+	if got := FlatMap(NewSequence[int](), func(v int) iter.Seq[int] { return nil }); !got.IsEmpty() {
+		t.Errorf("FlatMap() on empty collection = %v", got)
+	}
+}
+
+func Test_Reduce(t *testing.T) {
+	sum := func(acc, v int) int { return acc + v }
+	cases := []struct {
+		name    string
+		coll    Base[int]
+		want    int
+		wantErr error
+	}{
+		{name: "Reduce() on empty collection", coll: NewSequence[int](), wantErr: ErrEmptyCollection},
+		{name: "Reduce() on one-item collection", coll: NewListFrom([]int{111}), want: 111},
+		{name: "Reduce() on three-item collection", coll: NewSequenceFrom([]int{111, 222, 333}), want: 666},
+		{name: "Reduce() on SortedSet", coll: NewSortedSetFrom([]int{3, 1, 2}), want: 6},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Reduce(tt.coll, sum)
+			if got != tt.want || !errors.Is(err, tt.wantErr) {
+				t.Errorf("Reduce() = %v, %v, want %v, %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("Reduce() combines elements in order", func(t *testing.T) {
+		got, _ := Reduce(NewSequenceFrom([]string{"a", "b", "c"}), func(acc, v string) string { return acc + v })
+		if got != "abc" {
+			t.Errorf("Reduce() = %q, want %q", got, "abc")
+		}
+	})
+}
+
+func Test_FoldAndFoldRight(t *testing.T) {
+	concat := func(acc string, v int) string { return acc + strconv.Itoa(v) }
+	cases := []struct {
+		name      string
+		coll      Ordered[int]
+		initial   string
+		want      string
+		wantRight string
+	}{
+		{name: "empty Sequence", coll: NewSequence[int](), initial: "x", want: "x", wantRight: "x"},
+		{name: "three-item Sequence", coll: NewSequenceFrom([]int{1, 2, 3}), initial: ">", want: ">123", wantRight: ">321"},
+		{name: "three-item List", coll: NewListFrom([]int{1, 2, 3}), initial: "", want: "123", wantRight: "321"},
+		{name: "three-item Deque", coll: NewDequeFrom([]int{1, 2, 3}), initial: "", want: "123", wantRight: "321"},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Fold(tt.coll, tt.initial, concat); got != tt.want {
+				t.Errorf("Fold() = %q, want %q", got, tt.want)
+			}
+			if got := FoldRight(tt.coll, tt.initial, concat); got != tt.wantRight {
+				t.Errorf("FoldRight() = %q, want %q", got, tt.wantRight)
+			}
+		})
+	}
+
+	t.Run("Fold() into a composite result", func(t *testing.T) {
+		m := NewMapFrom([]Pair[string, int]{NewPair("a", 1), NewPair("b", 2)})
+		got := Fold(m, NewSequence[string](), func(acc Sequence[string], p Pair[string, int]) Sequence[string] {
+			acc.Append(strings.Repeat(p.Key(), p.Val()))
+			return acc
+		})
+		if !reflect.DeepEqual(got, NewSequenceFrom([]string{"a", "bb"})) {
+			t.Errorf("Fold() = %v", got)
+		}
+	})
+}
+
+func Test_Scan(t *testing.T) {
+	cases := []struct {
+		name    string
+		coll    Base[int]
+		initial int
+		want    []int
+	}{
+		{name: "Scan() on empty collection", coll: NewSequence[int](), initial: 100, want: []int(nil)},
+		{name: "Scan() on running balance", coll: NewSequenceFrom([]int{10, -5, 20, -30}), initial: 100, want: []int{110, 105, 125, 95}},
+		{name: "Scan() on List", coll: NewListFrom([]int{1, 2, 3}), initial: 0, want: []int{1, 3, 6}},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Scan(tt.coll, tt.initial, func(acc, v int) int { return acc + v })
+			if !reflect.DeepEqual(got, NewSequenceFrom(tt.want)) {
+				t.Errorf("Scan() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_GroupBy(t *testing.T) {
+	words := NewSequenceFrom([]string{"bob", "alice", "bill", "carol", "amy", "bea"})
+	got := GroupBy(words, func(w string) byte { return w[0] })
+
+	want := NewMapFrom([]Pair[byte, Sequence[string]]{
+		NewPair(byte('b'), NewSequenceFrom([]string{"bob", "bill", "bea"})),
+		NewPair(byte('a'), NewSequenceFrom([]string{"alice", "amy"})),
+		NewPair(byte('c'), NewSequenceFrom([]string{"carol"})),
+	})
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GroupBy() = %v, want %v", got, want)
+	}
+	if got := GroupBy(NewSequence[string](), func(w string) byte { return w[0] }); !got.IsEmpty() {
+		t.Errorf("GroupBy() on empty collection = %v", got)
+	}
+}
+
+func Test_Partition(t *testing.T) {
+	cases := []struct {
+		name         string
+		coll         Base[int]
+		wantMatching []int
+		wantRest     []int
+	}{
+		{name: "Partition() on empty collection", coll: NewSequence[int](), wantMatching: nil, wantRest: nil},
+		{name: "Partition() on mixed collection", coll: NewListFrom([]int{5, 2, 8, 1, 4}), wantMatching: []int{2, 8, 4}, wantRest: []int{5, 1}},
+		{name: "Partition() with all matching", coll: NewSequenceFrom([]int{2, 4}), wantMatching: []int{2, 4}, wantRest: nil},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			matching, rest := Partition(tt.coll, func(v int) bool { return v%2 == 0 })
+			if got := slices.Collect(matching.Values()); !reflect.DeepEqual(got, tt.wantMatching) {
+				t.Errorf("Partition() matching = %v, want %v", got, tt.wantMatching)
+			}
+			if got := slices.Collect(rest.Values()); !reflect.DeepEqual(got, tt.wantRest) {
+				t.Errorf("Partition() rest = %v, want %v", got, tt.wantRest)
+			}
+		})
+	}
+}
+
+func Test_CountBy(t *testing.T) {
+	words := NewSequenceFrom([]string{"go", "rust", "c", "zig", "java", "d"})
+	got := CountBy(words, func(w string) int { return len(w) })
+
+	want := NewCmpMapFrom([]Pair[int, int]{NewPair(2, 1), NewPair(4, 2), NewPair(1, 2), NewPair(3, 1)})
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CountBy() = %v, want %v", got, want)
+	}
+	if count := got.CountValues(2); count != 2 {
+		t.Errorf("CountValues(2) = %d, want 2", count)
+	}
+	if got := CountBy(NewSequence[string](), func(w string) int { return len(w) }); !got.IsEmpty() {
+		t.Errorf("CountBy() on empty collection = %v", got)
+	}
+}
+
+func collectSequences[V any](seq iter.Seq[Sequence[V]]) [][]V {
+	all := [][]V(nil)
+	for s := range seq {
+		all = append(all, slices.Collect(s.Values()))
+	}
+	return all
+}
+
+func Test_Chunk(t *testing.T) {
+	cases := []struct {
+		name string
+		coll Ordered[int]
+		size int
+		want [][]int
+	}{
+		{name: "Chunk() on empty collection", coll: NewSequence[int](), size: 2, want: nil},
+		{name: "Chunk() with remainder", coll: NewSequenceFrom([]int{1, 2, 3, 4, 5}), size: 2, want: [][]int{{1, 2}, {3, 4}, {5}}},
+		{name: "Chunk() without remainder", coll: NewListFrom([]int{1, 2, 3, 4}), size: 2, want: [][]int{{1, 2}, {3, 4}}},
+		{name: "Chunk() larger than collection", coll: NewSequenceFrom([]int{1, 2}), size: 5, want: [][]int{{1, 2}}},
+		{name: "Chunk() of size one", coll: NewSequenceFrom([]int{1, 2}), size: 1, want: [][]int{{1}, {2}}},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := collectSequences(Chunk(tt.coll, tt.size)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Chunk() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("Chunk() stops", func(t *testing.T) {
+		visited := 0
+		coll := NewSequenceFrom([]int{1, 2, 3, 4, 5})
+		for chunk := range Chunk(coll, 2) {
+			visited += chunk.Len()
+			break
+		}
+		if visited != 2 {
+			t.Errorf("Chunk() visited %d elements, want 2", visited)
+		}
+	})
+
+	t.Run("Chunk() yields independent sequences", func(t *testing.T) {
+		coll := NewSequenceFrom([]int{1, 2, 3})
+		for chunk := range Chunk(coll, 2) {
+			chunk.Apply(func(v int) int { return 0 })
+		}
+		if got := slices.Collect(coll.Values()); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+			t.Errorf("modifying a chunk modified the collection: %v", got)
+		}
+	})
+}
+
+func Test_Window(t *testing.T) {
+	coll := NewSequenceFrom([]int{1, 2, 3, 4, 5, 6})
+	cases := []struct {
+		name       string
+		coll       Ordered[int]
+		size, step int
+		want       [][]int
+	}{
+		{name: "Window() sliding by one", coll: coll, size: 3, step: 1, want: [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}, {4, 5, 6}}},
+		{name: "Window() sliding by two", coll: coll, size: 3, step: 2, want: [][]int{{1, 2, 3}, {3, 4, 5}}},
+		{name: "Window() tumbling", coll: coll, size: 2, step: 2, want: [][]int{{1, 2}, {3, 4}, {5, 6}}},
+		{name: "Window() skipping", coll: coll, size: 2, step: 3, want: [][]int{{1, 2}, {4, 5}}},
+		{name: "Window() larger than collection", coll: coll, size: 7, step: 1, want: nil},
+		{name: "Window() on List", coll: NewListFrom([]int{1, 2, 3}), size: 2, step: 1, want: [][]int{{1, 2}, {2, 3}}},
+		{name: "Window() on empty collection", coll: NewSequence[int](), size: 1, step: 1, want: nil},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := collectSequences(Window(tt.coll, tt.size, tt.step)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Window() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("Window() stops", func(t *testing.T) {
+		count := 0
+		for range Window(coll, 2, 1) {
+			count++
+			if count == 2 {
+				break
+			}
+		}
+		if count != 2 {
+			t.Errorf("Window() did not stop, count = %d", count)
+		}
+	})
+}
+
+func Test_Chunk_Window_invalidArguments(t *testing.T) {
+	cases := []struct {
+		name string
+		call func()
+		want string
+	}{
+		{name: "Chunk() with size 0", call: func() { Chunk(NewSequence[int](), 0) }, want: "Chunk() requires a size of at least 1"},
+		{name: "Window() with size 0", call: func() { Window(NewSequence[int](), 0, 1) }, want: "Window() requires a size and a step of at least 1"},
+		{name: "Window() with step 0", call: func() { Window(NewSequence[int](), 1, 0) }, want: "Window() requires a size and a step of at least 1"},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != tt.want {
+					t.Errorf("%s panicked with %v, want %q", tt.name, r, tt.want)
+				}
+			}()
+			tt.call()
+		})
+	}
+}
+
+func Test_Pairwise(t *testing.T) {
+	cases := []struct {
+		name string
+		coll Ordered[int]
+		want [][2]int
+	}{
+		{name: "Pairwise() on empty collection", coll: NewSequence[int](), want: nil},
+		{name: "Pairwise() on one-item collection", coll: NewSequenceFrom([]int{1}), want: nil},
+		{name: "Pairwise() on three-item collection", coll: NewListFrom([]int{1, 2, 3}), want: [][2]int{{1, 2}, {2, 3}}},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			got := [][2]int(nil)
+			for a, b := range Pairwise(tt.coll) {
+				got = append(got, [2]int{a, b})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Pairwise() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("Pairwise() stops", func(t *testing.T) {
+		count := 0
+		for range Pairwise(NewSequenceFrom([]int{1, 2, 3, 4})) {
+			count++
+			break
+		}
+		if count != 1 {
+			t.Errorf("Pairwise() did not stop, count = %d", count)
+		}
+	})
+}
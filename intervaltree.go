@@ -0,0 +1,239 @@
+package coll
+
+import (
+	"cmp"
+)
+
+// intervalTree is an AVL tree of half-open intervals that may overlap.
+// Nodes are ordered by lo, then by hi, then by insertion order, and every node is augmented with the greatest hi
+// in its subtree, which allows for finding all intervals that overlap a range in O(log n + m).
+type intervalTree[K cmp.Ordered, V any] struct {
+	root *intervalTreeNode[K, V]
+	size int
+	seq  uint64
+}
+
+type intervalTreeNode[K cmp.Ordered, V any] struct {
+	lo     K
+	hi     K
+	id     uint64
+	val    V
+	maxHi  K
+	left   *intervalTreeNode[K, V]
+	right  *intervalTreeNode[K, V]
+	height int
+}
+
+func (t *intervalTree[K, V]) len() int {
+	return t.size
+}
+
+func (t *intervalTree[K, V]) clear() {
+	t.root = nil
+	t.size = 0
+}
+
+// insert adds a new interval, even if an equal one is already present.
+func (t *intervalTree[K, V]) insert(lo, hi K, val V) {
+	t.seq++
+	t.insertNode(&intervalTreeNode[K, V]{lo: lo, hi: hi, id: t.seq, val: val})
+}
+
+// insertNode adds the given detached node, keeping its id and therefore its position among equal intervals.
+func (t *intervalTree[K, V]) insertNode(n *intervalTreeNode[K, V]) {
+	n.left, n.right = nil, nil
+	n.update()
+	t.root = t.root.insert(n)
+	t.size++
+}
+
+// delete removes the given node, which must belong to the tree.
+func (t *intervalTree[K, V]) delete(n *intervalTreeNode[K, V]) {
+	t.root = t.root.delete(n)
+	t.size--
+}
+
+// ascend visits all nodes in ascending order until visit returns false.
+func (t *intervalTree[K, V]) ascend(visit func(n *intervalTreeNode[K, V]) bool) {
+	t.root.ascend(visit)
+}
+
+// descend visits all nodes in descending order until visit returns false.
+func (t *intervalTree[K, V]) descend(visit func(n *intervalTreeNode[K, V]) bool) {
+	t.root.descend(visit)
+}
+
+// overlapping visits, in ascending order, the nodes whose hi is greater than from and whose lo matches before,
+// until visit returns false. The before predicate must be monotonic: true for all keys up to some boundary.
+func (t *intervalTree[K, V]) overlapping(from K, before func(lo K) bool, visit func(n *intervalTreeNode[K, V]) bool) {
+	t.root.overlapping(from, before, visit)
+}
+
+// clone creates a structural copy of the tree.
+func (t *intervalTree[K, V]) clone() intervalTree[K, V] {
+	return intervalTree[K, V]{root: t.root.clone(), size: t.size, seq: t.seq}
+}
+
+// Node functions:
+
+func (n *intervalTreeNode[K, V]) compare(other *intervalTreeNode[K, V]) int {
+	if c := cmp.Compare(n.lo, other.lo); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(n.hi, other.hi); c != 0 {
+		return c
+	}
+	return cmp.Compare(n.id, other.id)
+}
+
+func (n *intervalTreeNode[K, V]) getHeight() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func (n *intervalTreeNode[K, V]) update() {
+	n.height = 1 + max(n.left.getHeight(), n.right.getHeight())
+	n.maxHi = n.hi
+	if n.left != nil && n.left.maxHi > n.maxHi {
+		n.maxHi = n.left.maxHi
+	}
+	if n.right != nil && n.right.maxHi > n.maxHi {
+		n.maxHi = n.right.maxHi
+	}
+}
+
+func (n *intervalTreeNode[K, V]) rotateLeft() *intervalTreeNode[K, V] {
+	r := n.right
+	n.right = r.left
+	r.left = n
+	n.update()
+	r.update()
+	return r
+}
+
+func (n *intervalTreeNode[K, V]) rotateRight() *intervalTreeNode[K, V] {
+	l := n.left
+	n.left = l.right
+	l.right = n
+	n.update()
+	l.update()
+	return l
+}
+
+func (n *intervalTreeNode[K, V]) rebalance() *intervalTreeNode[K, V] {
+	n.update()
+	balance := n.left.getHeight() - n.right.getHeight()
+	if balance > 1 {
+		if n.left.left.getHeight() < n.left.right.getHeight() {
+			n.left = n.left.rotateLeft()
+		}
+		return n.rotateRight()
+	}
+	if balance < -1 {
+		if n.right.right.getHeight() < n.right.left.getHeight() {
+			n.right = n.right.rotateRight()
+		}
+		return n.rotateLeft()
+	}
+	return n
+}
+
+func (n *intervalTreeNode[K, V]) insert(node *intervalTreeNode[K, V]) *intervalTreeNode[K, V] {
+	if n == nil {
+		return node
+	}
+	if node.compare(n) < 0 {
+		n.left = n.left.insert(node)
+	} else {
+		n.right = n.right.insert(node)
+	}
+	return n.rebalance()
+}
+
+func (n *intervalTreeNode[K, V]) delete(node *intervalTreeNode[K, V]) *intervalTreeNode[K, V] {
+	if n == nil {
+		return nil
+	}
+	switch c := node.compare(n); {
+	case c < 0:
+		n.left = n.left.delete(node)
+	case c > 0:
+		n.right = n.right.delete(node)
+	default:
+		if n.left == nil {
+			return n.right
+		}
+		if n.right == nil {
+			return n.left
+		}
+		var successor *intervalTreeNode[K, V]
+		right := n.right.deleteMin(&successor)
+		successor.left = n.left
+		successor.right = right
+		return successor.rebalance()
+	}
+	return n.rebalance()
+}
+
+func (n *intervalTreeNode[K, V]) deleteMin(removed **intervalTreeNode[K, V]) *intervalTreeNode[K, V] {
+	if n.left == nil {
+		*removed = n
+		return n.right
+	}
+	n.left = n.left.deleteMin(removed)
+	return n.rebalance()
+}
+
+func (n *intervalTreeNode[K, V]) ascend(visit func(n *intervalTreeNode[K, V]) bool) bool {
+	if n == nil {
+		return true
+	}
+	return n.left.ascend(visit) && visit(n) && n.right.ascend(visit)
+}
+
+func (n *intervalTreeNode[K, V]) descend(visit func(n *intervalTreeNode[K, V]) bool) bool {
+	if n == nil {
+		return true
+	}
+	return n.right.descend(visit) && visit(n) && n.left.descend(visit)
+}
+
+func (n *intervalTreeNode[K, V]) overlapping(
+	from K,
+	before func(lo K) bool,
+	visit func(n *intervalTreeNode[K, V]) bool,
+) bool {
+	// No interval in the subtree reaches past from.
+	if n == nil || n.maxHi <= from {
+		return true
+	}
+	if !n.left.overlapping(from, before, visit) {
+		return false
+	}
+	// Neither this node nor the nodes to its right start early enough.
+	if !before(n.lo) {
+		return true
+	}
+	if n.hi > from && !visit(n) {
+		return false
+	}
+	return n.right.overlapping(from, before, visit)
+}
+
+func (n *intervalTreeNode[K, V]) clone() *intervalTreeNode[K, V] {
+	if n == nil {
+		return nil
+	}
+	return &intervalTreeNode[K, V]{
+		lo:     n.lo,
+		hi:     n.hi,
+		id:     n.id,
+		val:    n.val,
+		maxHi:  n.maxHi,
+		left:   n.left.clone(),
+		right:  n.right.clone(),
+		height: n.height,
+	}
+}
@@ -0,0 +1,135 @@
+package coll
+
+import (
+	"cmp"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+type intervalTreeTestEntry struct {
+	lo, hi int
+	id     uint64
+}
+
+func intervalTreeCheckInvariants[K cmp.Ordered, V any](t *testing.T, n *intervalTreeNode[K, V]) {
+	t.Helper()
+	if n == nil {
+		return
+	}
+	if n.height != 1+max(n.left.getHeight(), n.right.getHeight()) {
+		t.Fatalf("node [%v, %v) has invalid height %d", n.lo, n.hi, n.height)
+	}
+	if balance := n.left.getHeight() - n.right.getHeight(); balance > 1 || balance < -1 {
+		t.Fatalf("node [%v, %v) is unbalanced: %d", n.lo, n.hi, balance)
+	}
+	maxHi := n.hi
+	if n.left != nil {
+		if n.left.compare(n) >= 0 {
+			t.Fatalf("node [%v, %v) has invalid left child [%v, %v)", n.lo, n.hi, n.left.lo, n.left.hi)
+		}
+		maxHi = max(maxHi, n.left.maxHi)
+	}
+	if n.right != nil {
+		if n.right.compare(n) <= 0 {
+			t.Fatalf("node [%v, %v) has invalid right child [%v, %v)", n.lo, n.hi, n.right.lo, n.right.hi)
+		}
+		maxHi = max(maxHi, n.right.maxHi)
+	}
+	if n.maxHi != maxHi {
+		t.Fatalf("node [%v, %v) has maxHi %v, want %v", n.lo, n.hi, n.maxHi, maxHi)
+	}
+	intervalTreeCheckInvariants(t, n.left)
+	intervalTreeCheckInvariants(t, n.right)
+}
+
+func Test_intervalTree_randomOperations(t *testing.T) {
+	rnd := rand.New(rand.NewSource(42))
+	tree := &intervalTree[int, int]{}
+	reference := []intervalTreeTestEntry(nil)
+
+	for range 2000 {
+		if len(reference) > 0 && rnd.Intn(3) == 0 {
+			i := rnd.Intn(len(reference))
+			e := reference[i]
+			var node *intervalTreeNode[int, int]
+			tree.ascend(func(n *intervalTreeNode[int, int]) bool {
+				if n.id == e.id {
+					node = n
+				}
+				return node == nil
+			})
+			tree.delete(node)
+			reference = slices.Delete(reference, i, i+1)
+		} else {
+			lo := rnd.Intn(100)
+			hi := lo + 1 + rnd.Intn(20)
+			tree.insert(lo, hi, 0)
+			reference = append(reference, intervalTreeTestEntry{lo: lo, hi: hi, id: tree.seq})
+		}
+		intervalTreeCheckInvariants(t, tree.root)
+		if tree.len() != len(reference) {
+			t.Fatalf("len() = %d, want %d", tree.len(), len(reference))
+		}
+	}
+
+	slices.SortFunc(reference, func(a, b intervalTreeTestEntry) int {
+		return cmp.Or(cmp.Compare(a.lo, b.lo), cmp.Compare(a.hi, b.hi), cmp.Compare(a.id, b.id))
+	})
+	for range 200 {
+		lo := rnd.Intn(120)
+		hi := lo + 1 + rnd.Intn(20)
+		want := []intervalTreeTestEntry(nil)
+		for _, e := range reference {
+			if e.lo < hi && e.hi > lo {
+				want = append(want, e)
+			}
+		}
+		got := []intervalTreeTestEntry(nil)
+		tree.overlapping(lo, func(nLo int) bool { return nLo < hi }, func(n *intervalTreeNode[int, int]) bool {
+			got = append(got, intervalTreeTestEntry{lo: n.lo, hi: n.hi, id: n.id})
+			return true
+		})
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("overlapping(%d, %d) = %v, want %v", lo, hi, got, want)
+		}
+	}
+}
+
+func Test_intervalTree_overlappingStops(t *testing.T) {
+	tree := &intervalTree[int, string]{}
+	for i := range 10 {
+		tree.insert(i, i+5, "")
+	}
+
+	count := 0
+	tree.overlapping(0, func(lo int) bool { return lo < 100 }, func(*intervalTreeNode[int, string]) bool {
+		count++
+		return count < 3
+	})
+	if count != 3 {
+		t.Errorf("overlapping() did not stop, count = %d", count)
+	}
+}
+
+func Test_intervalTree_clone(t *testing.T) {
+	tree := &intervalTree[int, string]{}
+	tree.insert(0, 5, "a")
+	tree.insert(3, 8, "b")
+	clone := tree.clone()
+
+	tree.insert(1, 2, "c")
+	tree.ascend(func(n *intervalTreeNode[int, string]) bool {
+		n.val = "x"
+		return true
+	})
+	vals := []string(nil)
+	clone.ascend(func(n *intervalTreeNode[int, string]) bool {
+		vals = append(vals, n.val)
+		return true
+	})
+	if !reflect.DeepEqual(vals, []string{"a", "b"}) || clone.len() != 2 {
+		t.Errorf("clone() did not create a deep copy: %v", vals)
+	}
+}
@@ -0,0 +1,153 @@
+package coll
+
+import (
+	"cmp"
+	"container/heap"
+	"iter"
+)
+
+// KeyedPriorityQueue is a priority queue in which every key appears at most once
+// and its priority, the value, can be changed in O(log n).
+// The pair with the smallest value according to the Comparator given at construction is at the top.
+//
+// Pairs returned by PeekMin and yielded by Values are copies: changing them with SetVal does not modify the queue,
+// because it would bypass the reordering of the heap. Use Set instead.
+type KeyedPriorityQueue[K comparable, V any] interface {
+	BasePairs[K, V]
+
+	// Clear removes all pairs from the queue.
+	Clear()
+
+	// Get returns the value for the given key.
+	Get(k K) (V, bool)
+
+	// Has returns true if the queue contains the given key.
+	Has(k K) bool
+
+	// PeekMin returns the pair with the smallest value without removing it.
+	// Returns ErrEmptyCollection if the queue is empty.
+	PeekMin() (Pair[K, V], error)
+
+	// PopMin removes and returns the pair with the smallest value.
+	// Returns ErrEmptyCollection if the queue is empty.
+	PopMin() (Pair[K, V], error)
+
+	// Remove removes the pair with the given key.
+	Remove(k K)
+
+	// Set adds the key with the given value, or changes the value of an existing key.
+	Set(k K, v V)
+}
+
+type comfyKeyedPriorityQueue[K comparable, V any] struct {
+	h *priorityHeap[Pair[K, V]]
+	m map[K]*priorityQueueItem[Pair[K, V]]
+}
+
+// NewKeyedPriorityQueue creates a new KeyedPriorityQueue instance that orders values with the given comparator.
+func NewKeyedPriorityQueue[K comparable, V any](cmp Comparator[V]) KeyedPriorityQueue[K, V] {
+	return &comfyKeyedPriorityQueue[K, V]{
+		h: &priorityHeap[Pair[K, V]]{
+			cmp: func(a, b Pair[K, V]) int {
+				return cmp(a.Val(), b.Val())
+			},
+		},
+		m: make(map[K]*priorityQueueItem[Pair[K, V]]),
+	}
+}
+
+// NewCmpKeyedPriorityQueue creates a new KeyedPriorityQueue instance for cmp.Ordered values.
+func NewCmpKeyedPriorityQueue[K comparable, V cmp.Ordered]() KeyedPriorityQueue[K, V] {
+	return NewKeyedPriorityQueue[K](cmp.Compare[V])
+}
+
+func (c *comfyKeyedPriorityQueue[K, V]) Clear() {
+	c.h.items = nil
+	c.m = make(map[K]*priorityQueueItem[Pair[K, V]])
+}
+
+func (c *comfyKeyedPriorityQueue[K, V]) Get(k K) (V, bool) {
+	it, ok := c.m[k]
+	if !ok {
+		var v V
+		return v, false
+	}
+	return it.val.Val(), true
+}
+
+func (c *comfyKeyedPriorityQueue[K, V]) Has(k K) bool {
+	_, ok := c.m[k]
+	return ok
+}
+
+func (c *comfyKeyedPriorityQueue[K, V]) IsEmpty() bool {
+	return c.h.Len() == 0
+}
+
+func (c *comfyKeyedPriorityQueue[K, V]) Len() int {
+	return c.h.Len()
+}
+
+func (c *comfyKeyedPriorityQueue[K, V]) PeekMin() (Pair[K, V], error) {
+	if c.h.Len() == 0 {
+		return nil, ErrEmptyCollection
+	}
+	return c.h.items[0].val.copy(), nil
+}
+
+func (c *comfyKeyedPriorityQueue[K, V]) PopMin() (Pair[K, V], error) {
+	if c.h.Len() == 0 {
+		return nil, ErrEmptyCollection
+	}
+	p := heap.Pop(c.h).(*priorityQueueItem[Pair[K, V]]).val
+	delete(c.m, p.Key())
+	return p, nil
+}
+
+func (c *comfyKeyedPriorityQueue[K, V]) Remove(k K) {
+	it, ok := c.m[k]
+	if !ok {
+		return
+	}
+	heap.Remove(c.h, it.index)
+	delete(c.m, k)
+}
+
+func (c *comfyKeyedPriorityQueue[K, V]) Set(k K, v V) {
+	if it, ok := c.m[k]; ok {
+		it.val = NewPair(k, v)
+		heap.Fix(c.h, it.index)
+		return
+	}
+	it := &priorityQueueItem[Pair[K, V]]{val: NewPair(k, v)}
+	heap.Push(c.h, it)
+	c.m[k] = it
+}
+
+// Values returns an iterator over copies of all pairs in the queue in no particular order.
+func (c *comfyKeyedPriorityQueue[K, V]) Values() iter.Seq[Pair[K, V]] {
+	return func(yield func(Pair[K, V]) bool) {
+		for _, it := range c.h.items {
+			if !yield(it.val.copy()) {
+				break
+			}
+		}
+	}
+}
+
+// Private:
+
+//nolint:unused
+func (c *comfyKeyedPriorityQueue[K, V]) copy() baseInternal[Pair[K, V]] {
+	newHeap := &priorityHeap[Pair[K, V]]{
+		items: make([]*priorityQueueItem[Pair[K, V]], len(c.h.items)),
+		cmp:   c.h.cmp,
+	}
+	newMap := make(map[K]*priorityQueueItem[Pair[K, V]], len(c.m))
+	for i, it := range c.h.items {
+		newIt := &priorityQueueItem[Pair[K, V]]{val: it.val.copy(), index: i, owner: newHeap}
+		newHeap.items[i] = newIt
+		newMap[it.val.Key()] = newIt
+	}
+	return &comfyKeyedPriorityQueue[K, V]{h: newHeap, m: newMap}
+}
@@ -0,0 +1,184 @@
+package coll
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestNewKeyedPriorityQueue(t *testing.T) {
+	pq := NewKeyedPriorityQueue[string](func(a, b int) int { return b - a })
+	if pq == nil {
+		t.Fatal("NewKeyedPriorityQueue[string, int]() returned nil")
+	}
+	pq.Set("a", 1)
+	pq.Set("c", 3)
+	pq.Set("b", 2)
+	if p, err := pq.PopMin(); err != nil || p.Key() != "c" || p.Val() != 3 {
+		t.Errorf("PopMin() = %v, %v, want (c, 3), nil", p, err)
+	}
+}
+
+func Test_comfyKeyedPriorityQueue_Set(t *testing.T) {
+	pq := NewCmpKeyedPriorityQueue[string, int]()
+	pq.Set("a", 10)
+	pq.Set("b", 20)
+	pq.Set("c", 30)
+	pq.Set("c", 5)
+	pq.Set("a", 40)
+
+	if pq.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", pq.Len())
+	}
+
+	want := [][2]any{{"c", 5}, {"b", 20}, {"a", 40}}
+	got := [][2]any(nil)
+	for !pq.IsEmpty() {
+		p, _ := pq.PopMin()
+		got = append(got, [2]any{p.Key(), p.Val()})
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("PopMin() order = %v, want %v", got, want)
+	}
+}
+
+func Test_comfyKeyedPriorityQueue_GetHas(t *testing.T) {
+	pq := NewCmpKeyedPriorityQueue[string, int]()
+	pq.Set("a", 1)
+
+	cases := []struct {
+		name    string
+		key     string
+		wantVal int
+		wantOk  bool
+	}{
+		{name: "Get() existing key", key: "a", wantVal: 1, wantOk: true},
+		{name: "Get() missing key", key: "x", wantVal: 0, wantOk: false},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			v, ok := pq.Get(tt.key)
+			if v != tt.wantVal || ok != tt.wantOk {
+				t.Errorf("Get(%q) = %v, %v, want %v, %v", tt.key, v, ok, tt.wantVal, tt.wantOk)
+			}
+			if has := pq.Has(tt.key); has != tt.wantOk {
+				t.Errorf("Has(%q) = %v, want %v", tt.key, has, tt.wantOk)
+			}
+		})
+	}
+}
+
+func Test_comfyKeyedPriorityQueue_Remove(t *testing.T) {
+	pq := NewCmpKeyedPriorityQueue[int, int]()
+	for i := range 10 {
+		pq.Set(i, 100-i)
+	}
+	pq.Remove(9)
+	pq.Remove(4)
+	pq.Remove(42)
+
+	if pq.Len() != 8 || pq.Has(9) || pq.Has(4) {
+		t.Errorf("Remove() resulted in Len() = %d, Has(9) = %v, Has(4) = %v", pq.Len(), pq.Has(9), pq.Has(4))
+	}
+	if p, err := pq.PeekMin(); err != nil || p.Key() != 8 {
+		t.Errorf("PeekMin() = %v, %v, want key 8", p, err)
+	}
+}
+
+func Test_comfyKeyedPriorityQueue_empty(t *testing.T) {
+	pq := NewCmpKeyedPriorityQueue[int, int]()
+	if p, err := pq.PeekMin(); !errors.Is(err, ErrEmptyCollection) || p != nil {
+		t.Errorf("PeekMin() on empty queue = %v, %v, want nil, ErrEmptyCollection", p, err)
+	}
+	if p, err := pq.PopMin(); !errors.Is(err, ErrEmptyCollection) || p != nil {
+		t.Errorf("PopMin() on empty queue = %v, %v, want nil, ErrEmptyCollection", p, err)
+	}
+
+	pq.Set(1, 1)
+	pq.Clear()
+	if !pq.IsEmpty() || pq.Has(1) {
+		t.Error("Clear() did not remove all pairs")
+	}
+}
+
+func Test_comfyKeyedPriorityQueue_randomOperations(t *testing.T) {
+	rnd := rand.New(rand.NewSource(42))
+	pq := NewCmpKeyedPriorityQueue[int, int]()
+	reference := map[int]int{}
+
+	for range 2000 {
+		k := rnd.Intn(100)
+		switch rnd.Intn(4) {
+		case 0:
+			pq.Remove(k)
+			delete(reference, k)
+		default:
+			v := rnd.Intn(1000)
+			pq.Set(k, v)
+			reference[k] = v
+		}
+	}
+
+	if pq.Len() != len(reference) {
+		t.Fatalf("Len() = %d, want %d", pq.Len(), len(reference))
+	}
+	prev := -1
+	for !pq.IsEmpty() {
+		p, _ := pq.PopMin()
+		if p.Val() < prev {
+			t.Fatalf("PopMin() returned %d after %d", p.Val(), prev)
+		}
+		if reference[p.Key()] != p.Val() {
+			t.Fatalf("PopMin() returned %v, want value %d", p, reference[p.Key()])
+		}
+		delete(reference, p.Key())
+		prev = p.Val()
+	}
+	if len(reference) != 0 {
+		t.Errorf("keys not popped: %v", reference)
+	}
+}
+
+func Test_comfyKeyedPriorityQueue_returnsCopies(t *testing.T) {
+	pq := NewCmpKeyedPriorityQueue[string, int]()
+	pq.Set("a", 1)
+	pq.Set("b", 5)
+	pq.Set("c", 10)
+
+	p, _ := pq.PeekMin()
+	p.SetVal(100)
+	for p := range pq.Values() {
+		p.SetVal(100)
+	}
+
+	if v, _ := pq.Get("a"); v != 1 {
+		t.Errorf("changing a returned pair modified the queue, Get(a) = %v", v)
+	}
+	if p, _ := pq.PopMin(); p.Key() != "a" || p.Val() != 1 {
+		t.Errorf("PopMin() = %v, want a: 1", p)
+	}
+	if p, _ := pq.PopMin(); p.Key() != "b" || p.Val() != 5 {
+		t.Errorf("PopMin() = %v, want b: 5", p)
+	}
+}
+
+func Test_comfyKeyedPriorityQueue_copy(t *testing.T) {
+	c1 := NewCmpKeyedPriorityQueue[string, int]()
+	c1.Set("a", 1)
+	c1.Set("b", 2)
+	c2 := Copy(c1)
+
+	c1.Set("a", 3)
+	p, _ := c1.PeekMin()
+	p.SetVal(-1)
+	if v, _ := c2.Get("a"); v != 1 {
+		t.Errorf("copy() did not create a deep copy, Get(a) = %v", v)
+	}
+	if p, _ := c2.PopMin(); p.Key() != "a" {
+		t.Errorf("PopMin() on copy = %v, want key a", p)
+	}
+	if !c1.Has("a") || c1.Len() != 2 {
+		t.Error("popping from the copy modified the original")
+	}
+}
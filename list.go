@@ -0,0 +1,277 @@
+package coll
+
+import (
+	"iter"
+	"slices"
+)
+
+type comfyListNode[V any] struct {
+	v    V
+	prev *comfyListNode[V]
+	next *comfyListNode[V]
+}
+
+type comfyList[V any] struct {
+	head *comfyListNode[V]
+	tail *comfyListNode[V]
+	size int
+}
+
+// NewList creates a new List instance.
+func NewList[V any]() List[V] {
+	return &comfyList[V]{}
+}
+
+// NewListFrom creates a new List instance from a slice.
+func NewListFrom[V any](s []V) List[V] {
+	l := &comfyList[V]{}
+	l.Append(s...)
+	return l
+}
+
+func (c *comfyList[V]) Append(v ...V) {
+	for _, v := range v {
+		c.pushBack(v)
+	}
+}
+
+func (c *comfyList[V]) AppendColl(coll Ordered[V]) {
+	c.Append(slices.Collect(coll.Values())...)
+}
+
+func (c *comfyList[V]) Apply(f Mapper[V]) {
+	for n := c.head; n != nil; n = n.next {
+		n.v = f(n.v)
+	}
+}
+
+func (c *comfyList[V]) At(i int) (V, bool) {
+	n := c.nodeAt(i)
+	if n == nil {
+		var v V
+		return v, false
+	}
+
+	return n.v, true
+}
+
+func (c *comfyList[V]) AtOrDefault(i int, defaultValue V) V {
+	n := c.nodeAt(i)
+	if n == nil {
+		return defaultValue
+	}
+
+	return n.v
+}
+
+func (c *comfyList[V]) Clear() {
+	c.head = nil
+	c.tail = nil
+	c.size = 0
+}
+
+func (c *comfyList[V]) InsertAt(i int, v V) error {
+	if i < 0 || i > c.size {
+		return ErrOutOfBounds
+	}
+
+	if i == c.size {
+		c.pushBack(v)
+		return nil
+	}
+
+	c.insertBefore(c.nodeAt(i), v)
+	return nil
+}
+
+func (c *comfyList[V]) IsEmpty() bool {
+	return c.size == 0
+}
+
+func (c *comfyList[V]) Len() int {
+	return c.size
+}
+
+func (c *comfyList[V]) Prepend(v ...V) {
+	for i := len(v) - 1; i >= 0; i-- {
+		c.pushFront(v[i])
+	}
+}
+
+func (c *comfyList[V]) RemoveAt(i int) (removed V, err error) {
+	n := c.nodeAt(i)
+	if n == nil {
+		return removed, ErrOutOfBounds
+	}
+
+	c.unlink(n)
+	return n.v, nil
+}
+
+func (c *comfyList[V]) RemoveMatching(predicate Predicate[V]) (count int) {
+	for n := c.head; n != nil; {
+		next := n.next
+		if predicate(n.v) {
+			c.unlink(n)
+			count++
+		}
+		n = next
+	}
+
+	return count
+}
+
+func (c *comfyList[V]) Reverse() {
+	for n := c.head; n != nil; n = n.prev {
+		n.prev, n.next = n.next, n.prev
+	}
+	c.head, c.tail = c.tail, c.head
+}
+
+func (c *comfyList[V]) Sort(cmp func(a, b V) int) {
+	s := slices.SortedFunc(c.Values(), cmp)
+	i := 0
+	for n := c.head; n != nil; n = n.next {
+		n.v = s[i]
+		i++
+	}
+}
+
+func (c *comfyList[V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for n := c.head; n != nil; n = n.next {
+			if !yield(n.v) {
+				break
+			}
+		}
+	}
+}
+
+func (c *comfyList[V]) ValuesRev() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for n := c.tail; n != nil; n = n.prev {
+			if !yield(n.v) {
+				break
+			}
+		}
+	}
+}
+
+// Private:
+
+//nolint:unused
+func (c *comfyList[V]) copy() baseInternal[V] {
+	newCl := &comfyList[V]{}
+	for n := c.head; n != nil; n = n.next {
+		newCl.pushBack(n.v)
+	}
+
+	return newCl
+}
+
+func (c *comfyList[V]) filter(predicate Predicate[V]) baseInternal[V] {
+	filtered := &comfyList[V]{}
+	for n := c.head; n != nil; n = n.next {
+		if predicate(n.v) {
+			filtered.pushBack(n.v)
+		}
+	}
+	return filtered
+}
+
+func (c *comfyList[V]) remap(f Mapper[V]) baseInternal[V] {
+	mapped := &comfyList[V]{}
+	for n := c.head; n != nil; n = n.next {
+		mapped.pushBack(f(n.v))
+	}
+	return mapped
+}
+
+// nodeAt returns the node at the given index, walking from whichever end of the list is closer.
+// Returns nil if the index is out of bounds.
+func (c *comfyList[V]) nodeAt(i int) *comfyListNode[V] {
+	if i < 0 || i >= c.size {
+		return nil
+	}
+
+	if i < c.size/2 {
+		n := c.head
+		for ; i > 0; i-- {
+			n = n.next
+		}
+		return n
+	}
+
+	n := c.tail
+	for i = c.size - 1 - i; i > 0; i-- {
+		n = n.prev
+	}
+	return n
+}
+
+func (c *comfyList[V]) pushBack(v V) *comfyListNode[V] {
+	n := &comfyListNode[V]{v: v, prev: c.tail}
+	if c.tail == nil {
+		c.head = n
+	} else {
+		c.tail.next = n
+	}
+	c.tail = n
+	c.size++
+
+	return n
+}
+
+func (c *comfyList[V]) pushFront(v V) *comfyListNode[V] {
+	n := &comfyListNode[V]{v: v, next: c.head}
+	if c.head == nil {
+		c.tail = n
+	} else {
+		c.head.prev = n
+	}
+	c.head = n
+	c.size++
+
+	return n
+}
+
+func (c *comfyList[V]) insertBefore(mark *comfyListNode[V], v V) *comfyListNode[V] {
+	if mark == c.head {
+		return c.pushFront(v)
+	}
+
+	n := &comfyListNode[V]{v: v, prev: mark.prev, next: mark}
+	mark.prev.next = n
+	mark.prev = n
+	c.size++
+
+	return n
+}
+
+func (c *comfyList[V]) moveToBack(n *comfyListNode[V]) {
+	if n == c.tail {
+		return
+	}
+
+	c.unlink(n)
+	n.prev = c.tail
+	c.tail.next = n
+	c.tail = n
+	c.size++
+}
+
+func (c *comfyList[V]) unlink(n *comfyListNode[V]) {
+	if n.prev == nil {
+		c.head = n.next
+	} else {
+		n.prev.next = n.next
+	}
+	if n.next == nil {
+		c.tail = n.prev
+	} else {
+		n.next.prev = n.prev
+	}
+	n.prev = nil
+	n.next = nil
+	c.size--
+}
@@ -0,0 +1,221 @@
+package coll
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+type comfyListIntBuilder[C baseInternal[int]] struct {
+}
+
+func (lcb *comfyListIntBuilder[C]) Empty() C {
+	return lcb.make([]int(nil)).(C)
+}
+
+func (lcb *comfyListIntBuilder[C]) One() C {
+	return lcb.make([]int{111}).(C)
+}
+
+func (lcb *comfyListIntBuilder[C]) Two() C {
+	return lcb.make([]int{123, 234}).(C)
+}
+
+func (lcb *comfyListIntBuilder[C]) Three() C {
+	return lcb.make([]int{111, 222, 333}).(C)
+}
+
+func (lcb *comfyListIntBuilder[C]) ThreeRev() C {
+	return lcb.make([]int{333, 222, 111}).(C)
+}
+
+func (lcb *comfyListIntBuilder[C]) SixWithDuplicates() C {
+	return lcb.make([]int{111, 222, 333, 111, 222, 333}).(C)
+}
+
+func (lcb *comfyListIntBuilder[C]) FromValues(values []any) C {
+	c := lcb.make([]int{})
+	for _, v := range values {
+		c.Append(v.(int))
+	}
+	return c.(C)
+}
+
+func (lcb *comfyListIntBuilder[C]) make(items []int) orderedMutableInternal[int] {
+	coll := &comfyList[int]{}
+	coll.Append(items...)
+
+	return coll
+}
+
+func (lcb *comfyListIntBuilder[C]) extractRawValues(c C) any {
+	return lcb.extractUnderlyingSlice(c)
+}
+
+func (lcb *comfyListIntBuilder[C]) extractUnderlyingSlice(c C) any {
+	return slices.Collect((any(c)).(*comfyList[int]).Values())
+}
+
+func (lcb *comfyListIntBuilder[C]) extractUnderlyingMap(_ C) any {
+	return nil
+}
+
+func (lcb *comfyListIntBuilder[C]) extractUnderlyingKp(_ C) any {
+	return nil
+}
+
+func (lcb *comfyListIntBuilder[C]) extractUnderlyingValsCount(_ C) any {
+	return nil
+}
+
+func TestNewList(t *testing.T) {
+	t.Run("NewList[int]()", func(t *testing.T) {
+		intList := NewList[int]()
+		if intList == nil {
+			t.Error("NewList[int]() returned nil")
+		}
+		if !reflect.DeepEqual(intList, &comfyList[int]{}) {
+			t.Error("NewList[int]() did not return a comfyList[int]")
+		}
+
+		stringList := NewList[string]()
+		if stringList == nil {
+			t.Error("NewList[string]() returned nil")
+		}
+		if !reflect.DeepEqual(stringList, &comfyList[string]{}) {
+			t.Error("NewList[string]() did not return a comfyList[string]")
+		}
+	})
+}
+
+func TestNewListFrom(t *testing.T) {
+	t.Run("NewListFrom[int]()", func(t *testing.T) {
+		intList := NewListFrom([]int{1, 2, 3})
+		if intList == nil {
+			t.Error("NewListFrom[int]() returned nil")
+		}
+		if got := slices.Collect(intList.Values()); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+			t.Errorf("NewListFrom[int]() resulted in: %v, but wanted %v", got, []int{1, 2, 3})
+		}
+		if intList.Len() != 3 {
+			t.Errorf("NewListFrom[int]() resulted in length %d, but wanted 3", intList.Len())
+		}
+	})
+}
+
+func Test_comfyList_Append(t *testing.T) {
+	testAppendOne(t, &comfyListIntBuilder[orderedMutableInternal[int]]{})
+	testAppendMany(t, &comfyListIntBuilder[orderedMutableInternal[int]]{})
+}
+
+func Test_comfyList_AppendColl(t *testing.T) {
+	testAppendColl(t, &comfyListIntBuilder[orderedMutableInternal[int]]{})
+}
+
+func Test_comfyList_Apply(t *testing.T) {
+	testApply(t, &comfyListIntBuilder[mutableInternal[int]]{})
+}
+
+func Test_comfyList_At(t *testing.T) {
+	testAt(t, &comfyListIntBuilder[indexedInternal[int]]{})
+}
+
+func Test_comfyList_AtOrDefault(t *testing.T) {
+	testAtOrDefault(t, &comfyListIntBuilder[indexedInternal[int]]{})
+}
+
+func Test_comfyList_Clear(t *testing.T) {
+	testClear(t, &comfyListIntBuilder[mutableInternal[int]]{})
+}
+
+func Test_comfyList_InsertAt(t *testing.T) {
+	testInsertAt(t, &comfyListIntBuilder[listInternal[int]]{})
+}
+
+func Test_comfyList_IsEmpty(t *testing.T) {
+	testIsEmpty(t, &comfyListIntBuilder[baseInternal[int]]{})
+}
+
+func Test_comfyList_Len(t *testing.T) {
+	testLen(t, &comfyListIntBuilder[baseInternal[int]]{})
+}
+
+func Test_comfyList_Prepend(t *testing.T) {
+	testPrependOne(t, &comfyListIntBuilder[orderedMutableInternal[int]]{})
+	testPrependMany(t, &comfyListIntBuilder[orderedMutableInternal[int]]{})
+}
+
+func Test_comfyList_RemoveAt(t *testing.T) {
+	testRemoveAt(t, &comfyListIntBuilder[indexedMutableInternal[int]]{})
+}
+
+func Test_comfyList_RemoveMatching(t *testing.T) {
+	testRemoveMatching(t, &comfyListIntBuilder[mutableInternal[int]]{})
+}
+
+func Test_comfyList_Reverse(t *testing.T) {
+	testReverse(t, &comfyListIntBuilder[orderedMutableInternal[int]]{})
+	testReverseTwice(t, &comfyListIntBuilder[orderedMutableInternal[int]]{})
+}
+
+func Test_comfyList_Sort(t *testing.T) {
+	testSort(t, &comfyListIntBuilder[indexedMutableInternal[int]]{})
+}
+
+func Test_comfyList_Values(t *testing.T) {
+	testValues(t, &comfyListIntBuilder[baseInternal[int]]{})
+	testValuesBreak(t, &comfyListIntBuilder[baseInternal[int]]{})
+}
+
+func Test_comfyList_ValuesRev(t *testing.T) {
+	testValuesRev(t, &comfyListIntBuilder[orderedInternal[int]]{})
+	testValuesRevBreak(t, &comfyListIntBuilder[orderedInternal[int]]{})
+}
+
+func Test_comfyList_copy(t *testing.T) {
+	testCopy(t, &comfyListIntBuilder[baseInternal[int]]{})
+}
+
+func Test_comfyList_copy_pointer(t *testing.T) {
+	c1 := NewListFrom([]int{123, 234, 345}).(*comfyList[int])
+	c2 := c1.copy()
+
+	t.Run("copy() creates a new instance", func(t *testing.T) {
+		if c1 == c2 {
+			t.Error("copy() did not create a new instance")
+		}
+	})
+
+	t.Run("copy() creates a deep copy", func(t *testing.T) {
+		c1.head.v = 999
+		for v := range c2.Values() {
+			if v == 999 {
+				t.Error("copy() did not create a deep copy")
+			}
+		}
+	})
+}
+
+func Test_comfyList_links(t *testing.T) {
+	t.Run("links stay consistent after mixed operations", func(t *testing.T) {
+		l := NewListFrom([]int{1, 2, 3, 4, 5}).(*comfyList[int])
+		l.Prepend(0)
+		_ = l.InsertAt(3, 99)
+		_, _ = l.RemoveAt(0)
+		_, _ = l.RemoveAt(l.Len() - 1)
+		l.RemoveMatching(func(v int) bool { return v == 3 })
+		l.Reverse()
+
+		want := []int{4, 99, 2, 1}
+		if got := slices.Collect(l.Values()); !reflect.DeepEqual(got, want) {
+			t.Errorf("Values() = %v, want %v", got, want)
+		}
+		slices.Reverse(want)
+		if got := slices.Collect(l.ValuesRev()); !reflect.DeepEqual(got, want) {
+			t.Errorf("ValuesRev() = %v, want %v", got, want)
+		}
+		if l.head.prev != nil || l.tail.next != nil {
+			t.Error("head or tail is linked outside of the list")
+		}
+	})
+}
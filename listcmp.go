@@ -0,0 +1,233 @@
+package coll
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// NewCmpList creates a new CmpList instance.
+func NewCmpList[V cmp.Ordered]() CmpList[V] {
+	return &comfyCmpList[V]{
+		l:  &comfyList[V]{},
+		vc: newValuesCounter[V](),
+	}
+}
+
+// NewCmpListFrom creates a new CmpList instance from a slice.
+func NewCmpListFrom[V cmp.Ordered](s []V) CmpList[V] {
+	cl := NewCmpList[V]()
+	cl.Append(s...)
+	return cl
+}
+
+type comfyCmpList[V cmp.Ordered] struct {
+	l  *comfyList[V]
+	vc *valuesCounter[V]
+}
+
+func (c *comfyCmpList[V]) Append(v ...V) {
+	for _, v := range v {
+		c.l.pushBack(v)
+		c.vc.Increment(v)
+	}
+}
+
+func (c *comfyCmpList[V]) AppendColl(coll Ordered[V]) {
+	c.Append(slices.Collect(coll.Values())...)
+}
+
+func (c *comfyCmpList[V]) Apply(f Mapper[V]) {
+	for n := c.l.head; n != nil; n = n.next {
+		c.vc.Decrement(n.v)
+		n.v = f(n.v)
+		c.vc.Increment(n.v)
+	}
+}
+
+func (c *comfyCmpList[V]) At(i int) (V, bool) {
+	return c.l.At(i)
+}
+
+func (c *comfyCmpList[V]) AtOrDefault(i int, defaultValue V) V {
+	return c.l.AtOrDefault(i, defaultValue)
+}
+
+func (c *comfyCmpList[V]) Clear() {
+	c.l.Clear()
+	c.vc = newValuesCounter[V]()
+}
+
+func (c *comfyCmpList[V]) ContainsValue(v V) bool {
+	return c.vc.Count(v) > 0
+}
+
+func (c *comfyCmpList[V]) CountValues(v V) int {
+	return c.vc.Count(v)
+}
+
+func (c *comfyCmpList[V]) HasValue(v V) bool {
+	return c.ContainsValue(v)
+}
+
+func (c *comfyCmpList[V]) IndexOf(v V) (i int, found bool) {
+	if c.vc.Count(v) == 0 {
+		return -1, false
+	}
+	i = 0
+	for n := c.l.head; n != nil; n = n.next {
+		if n.v == v {
+			return i, true
+		}
+		i++
+	}
+	panic("invalid internal state of comfyCmpList")
+}
+
+func (c *comfyCmpList[V]) InsertAt(i int, v V) error {
+	if err := c.l.InsertAt(i, v); err != nil {
+		return err
+	}
+	c.vc.Increment(v)
+	return nil
+}
+
+func (c *comfyCmpList[V]) IsEmpty() bool {
+	return c.l.IsEmpty()
+}
+
+func (c *comfyCmpList[V]) LastIndexOf(v V) (i int, found bool) {
+	if c.vc.Count(v) == 0 {
+		return -1, false
+	}
+	i = c.l.size - 1
+	for n := c.l.tail; n != nil; n = n.prev {
+		if n.v == v {
+			return i, true
+		}
+		i--
+	}
+	panic("invalid internal state of comfyCmpList")
+}
+
+func (c *comfyCmpList[V]) Len() int {
+	return c.l.Len()
+}
+
+func (c *comfyCmpList[V]) Prepend(v ...V) {
+	c.l.Prepend(v...)
+	for _, v := range v {
+		c.vc.Increment(v)
+	}
+}
+
+func (c *comfyCmpList[V]) RemoveAt(i int) (removed V, err error) {
+	if removed, err = c.l.RemoveAt(i); err != nil {
+		return removed, err
+	}
+	c.vc.Decrement(removed)
+
+	return removed, nil
+}
+
+func (c *comfyCmpList[V]) RemoveMatching(predicate Predicate[V]) (count int) {
+	return c.l.RemoveMatching(func(v V) bool {
+		doRemove := predicate(v)
+		if doRemove {
+			c.vc.Decrement(v)
+		}
+		return doRemove
+	})
+}
+
+func (c *comfyCmpList[V]) RemoveValues(v ...V) (count int) {
+	toRemove := newValuesCounter[V]()
+	for _, v := range v {
+		if c.vc.Count(v) > 0 {
+			toRemove.Set(v, c.vc.Count(v))
+		}
+	}
+
+	for n := c.l.head; n != nil && !toRemove.IsEmpty(); {
+		next := n.next
+		if toRemove.Count(n.v) > 0 {
+			toRemove.Decrement(n.v)
+			c.vc.Decrement(n.v)
+			c.l.unlink(n)
+			count++
+		}
+		n = next
+	}
+
+	return count
+}
+
+func (c *comfyCmpList[V]) Reverse() {
+	c.l.Reverse()
+}
+
+func (c *comfyCmpList[V]) Sort(cmp func(a, b V) int) {
+	c.l.Sort(cmp)
+}
+
+func (c *comfyCmpList[V]) SortAsc() {
+	c.Sort(func(a, b V) int {
+		if a < b {
+			return -1
+		} else if a > b {
+			return 1
+		}
+		return 0
+	})
+}
+
+func (c *comfyCmpList[V]) SortDesc() {
+	c.Sort(func(a, b V) int {
+		if a < b {
+			return 1
+		} else if a > b {
+			return -1
+		}
+		return 0
+	})
+}
+
+func (c *comfyCmpList[V]) Values() iter.Seq[V] {
+	return c.l.Values()
+}
+
+func (c *comfyCmpList[V]) ValuesRev() iter.Seq[V] {
+	return c.l.ValuesRev()
+}
+
+// Private:
+
+//nolint:unused
+func (c *comfyCmpList[V]) copy() baseInternal[V] {
+	ccl := &comfyCmpList[V]{
+		l:  &comfyList[V]{},
+		vc: newValuesCounter[V](),
+	}
+	for v := range c.l.Values() {
+		ccl.Append(v)
+	}
+	return ccl
+}
+
+func (c *comfyCmpList[V]) filter(predicate Predicate[V]) baseInternal[V] {
+	filtered := NewCmpList[V]().(*comfyCmpList[V])
+	for n := c.l.head; n != nil; n = n.next {
+		if predicate(n.v) {
+			filtered.Append(n.v)
+		}
+	}
+	return filtered
+}
+
+func (c *comfyCmpList[V]) remap(f Mapper[V]) baseInternal[V] {
+	mapped := NewCmpList[V]().(*comfyCmpList[V])
+	for n := c.l.head; n != nil; n = n.next {
+		mapped.Append(f(n.v))
+	}
+	return mapped
+}
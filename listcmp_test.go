@@ -0,0 +1,270 @@
+package coll
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+type comfyCmpListIntBuilder[C any] struct {
+}
+
+func (lcb *comfyCmpListIntBuilder[C]) Empty() C {
+	return lcb.make([]int(nil)).(C)
+}
+
+func (lcb *comfyCmpListIntBuilder[C]) One() C {
+	return lcb.make([]int{111}).(C)
+}
+
+func (lcb *comfyCmpListIntBuilder[C]) Two() C {
+	return lcb.make([]int{123, 234}).(C)
+}
+
+func (lcb *comfyCmpListIntBuilder[C]) Three() C {
+	return lcb.make([]int{111, 222, 333}).(C)
+}
+
+func (lcb *comfyCmpListIntBuilder[C]) ThreeRev() C {
+	return lcb.make([]int{333, 222, 111}).(C)
+}
+
+func (lcb *comfyCmpListIntBuilder[C]) SixWithDuplicates() C {
+	return lcb.make([]int{111, 222, 333, 111, 222, 333}).(C)
+}
+
+func (lcb *comfyCmpListIntBuilder[C]) FromValues(values []any) C {
+	c := lcb.make([]int{})
+	for _, v := range values {
+		c.Append(v.(int))
+	}
+	return c.(C)
+}
+
+func (lcb *comfyCmpListIntBuilder[C]) extractRawValues(coll C) any {
+	return lcb.extractUnderlyingSlice(coll)
+}
+
+func (lcb *comfyCmpListIntBuilder[C]) extractUnderlyingSlice(coll C) any {
+	return slices.Collect((any(coll)).(*comfyCmpList[int]).Values())
+}
+
+func (lcb *comfyCmpListIntBuilder[C]) extractUnderlyingMap(_ C) any {
+	return nil
+}
+
+func (lcb *comfyCmpListIntBuilder[C]) extractUnderlyingKp(_ C) any {
+	return nil
+}
+
+func (lcb *comfyCmpListIntBuilder[C]) extractUnderlyingValsCount(coll C) any {
+	vc := (any(coll)).(*comfyCmpList[int]).vc.counter
+	if vc == nil {
+		panic("Could not extract Values Counter from comfyCmpList")
+	}
+	return vc
+}
+
+func (lcb *comfyCmpListIntBuilder[C]) make(items []int) orderedMutableInternal[int] {
+	coll := &comfyCmpList[int]{
+		l:  &comfyList[int]{},
+		vc: newValuesCounter[int](),
+	}
+	coll.Append(items...)
+
+	return coll
+}
+
+func TestNewCmpList(t *testing.T) {
+	intList := NewCmpList[int]()
+	if intList == nil {
+		t.Error("NewCmpList[int]() returned nil")
+	}
+	if !reflect.DeepEqual(intList, &comfyCmpList[int]{l: &comfyList[int]{}, vc: newValuesCounter[int]()}) {
+		t.Error("NewCmpList[int]() did not return a comfyCmpList[int]")
+	}
+
+	stringList := NewCmpList[string]()
+	if stringList == nil {
+		t.Error("NewCmpList[string]() returned nil")
+	}
+	if !reflect.DeepEqual(stringList, &comfyCmpList[string]{l: &comfyList[string]{}, vc: newValuesCounter[string]()}) {
+		t.Error("NewCmpList[string]() did not return a comfyCmpList[string]")
+	}
+}
+
+func TestNewCmpListFrom(t *testing.T) {
+	intList := NewCmpListFrom([]int{1, 2, 3, 1})
+	if intList == nil {
+		t.Error("NewCmpListFrom[int]() returned nil")
+	}
+	if got := slices.Collect(intList.Values()); !reflect.DeepEqual(got, []int{1, 2, 3, 1}) {
+		t.Errorf("NewCmpListFrom[int]() resulted in: %v, but wanted %v", got, []int{1, 2, 3, 1})
+	}
+	wantVC := map[int]int{1: 2, 2: 1, 3: 1}
+	if got := intList.(*comfyCmpList[int]).vc.counter; !reflect.DeepEqual(got, wantVC) {
+		t.Errorf("NewCmpListFrom[int]() values counter = %v, but wanted %v", got, wantVC)
+	}
+}
+
+func Test_comfyCmpList_Append(t *testing.T) {
+	testAppendOne(t, &comfyCmpListIntBuilder[orderedMutableInternal[int]]{})
+	testAppendMany(t, &comfyCmpListIntBuilder[orderedMutableInternal[int]]{})
+}
+
+func Test_comfyCmpList_AppendColl(t *testing.T) {
+	testAppendColl(t, &comfyCmpListIntBuilder[orderedMutableInternal[int]]{})
+}
+
+func Test_comfyCmpList_Apply(t *testing.T) {
+	testApply(t, &comfyCmpListIntBuilder[mutableInternal[int]]{})
+}
+
+func Test_comfyCmpList_At(t *testing.T) {
+	testAt(t, &comfyCmpListIntBuilder[indexedInternal[int]]{})
+}
+
+func Test_comfyCmpList_AtOrDefault(t *testing.T) {
+	testAtOrDefault(t, &comfyCmpListIntBuilder[indexedInternal[int]]{})
+}
+
+func Test_comfyCmpList_Clear(t *testing.T) {
+	testClear(t, &comfyCmpListIntBuilder[mutableInternal[int]]{})
+}
+
+func Test_comfyCmpList_ContainsValue(t *testing.T) {
+	testContainsValue(t, &comfyCmpListIntBuilder[cmpBaseInternal[int, int]]{})
+}
+
+func Test_comfyCmpList_CountValues(t *testing.T) {
+	testCountValues(t, &comfyCmpListIntBuilder[cmpBaseInternal[int, int]]{})
+}
+
+func Test_comfyCmpList_HasValue(t *testing.T) {
+	testHasValue(t, &comfyCmpListIntBuilder[cmpBaseInternal[int, int]]{})
+}
+
+func Test_comfyCmpList_IndexOf(t *testing.T) {
+	testIndexOf(t, &comfyCmpListIntBuilder[cmpBaseInternal[int, int]]{})
+}
+
+func Test_comfyCmpList_IndexOfInvalidState(t *testing.T) {
+	t.Run("invalid internal state", func(t *testing.T) {
+		coll := NewCmpListFrom[int]([]int{1, 2, 3})
+		coll.(*comfyCmpList[int]).vc.Increment(4)
+		defer func() {
+			r := recover()
+			if r == nil {
+				t.Error("IndexOf() did not panic")
+			}
+			if r != "invalid internal state of comfyCmpList" {
+				t.Errorf("IndexOf() panicked with wrong error: %v", r)
+			}
+		}()
+		coll.IndexOf(4)
+	})
+}
+
+func Test_comfyCmpList_InsertAt(t *testing.T) {
+	testInsertAt(t, &comfyCmpListIntBuilder[listInternal[int]]{})
+}
+
+func Test_comfyCmpList_IsEmpty(t *testing.T) {
+	testIsEmpty(t, &comfyCmpListIntBuilder[baseInternal[int]]{})
+}
+
+func Test_comfyCmpList_LastIndexOf(t *testing.T) {
+	testLastIndexOf(t, &comfyCmpListIntBuilder[cmpBaseInternal[int, int]]{})
+}
+
+func Test_comfyCmpList_LastIndexOfInvalidState(t *testing.T) {
+	t.Run("invalid internal state", func(t *testing.T) {
+		coll := NewCmpListFrom[int]([]int{1, 2, 3})
+		coll.(*comfyCmpList[int]).vc.Increment(4)
+		defer func() {
+			r := recover()
+			if r == nil {
+				t.Error("LastIndexOf() did not panic")
+			}
+			if r != "invalid internal state of comfyCmpList" {
+				t.Errorf("LastIndexOf() panicked with wrong error: %v", r)
+			}
+		}()
+		coll.LastIndexOf(4)
+	})
+}
+
+func Test_comfyCmpList_Len(t *testing.T) {
+	testLen(t, &comfyCmpListIntBuilder[baseInternal[int]]{})
+}
+
+func Test_comfyCmpList_Prepend(t *testing.T) {
+	testPrependOne(t, &comfyCmpListIntBuilder[orderedMutableInternal[int]]{})
+	testPrependMany(t, &comfyCmpListIntBuilder[orderedMutableInternal[int]]{})
+}
+
+func Test_comfyCmpList_RemoveAt(t *testing.T) {
+	testRemoveAt(t, &comfyCmpListIntBuilder[indexedMutableInternal[int]]{})
+}
+
+func Test_comfyCmpList_RemoveMatching(t *testing.T) {
+	testRemoveMatching(t, &comfyCmpListIntBuilder[mutableInternal[int]]{})
+}
+
+func Test_comfyCmpList_RemoveValues(t *testing.T) {
+	testRemoveValues(t, &comfyCmpListIntBuilder[CmpMutable[int]]{})
+}
+
+func Test_comfyCmpList_Reverse(t *testing.T) {
+	testReverse(t, &comfyCmpListIntBuilder[orderedMutableInternal[int]]{})
+	testReverseTwice(t, &comfyCmpListIntBuilder[orderedMutableInternal[int]]{})
+}
+
+func Test_comfyCmpList_Sort(t *testing.T) {
+	testSort(t, &comfyCmpListIntBuilder[indexedMutableInternal[int]]{})
+}
+
+func Test_comfyCmpList_SortAsc(t *testing.T) {
+	testSortAsc(t, &comfyCmpListIntBuilder[cmpBaseMutableInternal[int, int]]{})
+}
+
+func Test_comfyCmpList_SortDesc(t *testing.T) {
+	testSortDesc(t, &comfyCmpListIntBuilder[CmpMutable[int]]{})
+}
+
+func Test_comfyCmpList_Values(t *testing.T) {
+	testValues(t, &comfyCmpListIntBuilder[baseInternal[int]]{})
+	testValuesBreak(t, &comfyCmpListIntBuilder[baseInternal[int]]{})
+}
+
+func Test_comfyCmpList_ValuesRev(t *testing.T) {
+	testValuesRev(t, &comfyCmpListIntBuilder[orderedInternal[int]]{})
+	testValuesRevBreak(t, &comfyCmpListIntBuilder[orderedInternal[int]]{})
+}
+
+func Test_comfyCmpList_copy(t *testing.T) {
+	testCopy(t, &comfyCmpListIntBuilder[baseInternal[int]]{})
+}
+
+func Test_comfyCmpList_copy_pointer(t *testing.T) {
+	c1 := NewCmpListFrom([]int{123, 234, 345}).(*comfyCmpList[int])
+	c2 := c1.copy()
+
+	t.Run("copy() creates a new instance", func(t *testing.T) {
+		if c1 == c2 {
+			t.Error("copy() did not create a new instance")
+		}
+	})
+
+	t.Run("copy() creates a deep copy", func(t *testing.T) {
+		c1.Apply(func(v int) int { return 999 })
+		for v := range c2.Values() {
+			if v == 999 {
+				t.Error("copy() did not create a deep copy")
+			}
+		}
+		if c2.(*comfyCmpList[int]).CountValues(999) != 0 {
+			t.Error("copy() shares the values counter with the original")
+		}
+	})
+}
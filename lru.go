@@ -0,0 +1,179 @@
+package coll
+
+import (
+	"iter"
+)
+
+// LRU is a fixed-capacity cache that evicts the least recently used pair when it is full.
+// Get and Set mark a key as the most recently used one, while Has and Peek leave the recency order untouched.
+// All operations are O(1).
+//
+// Values yields pairs from the least recently used to the most recently used one, ValuesRev in the opposite order.
+type LRU[K comparable, V any] interface {
+	Ordered[Pair[K, V]]
+
+	// Cap returns the maximum number of pairs the cache can hold.
+	Cap() int
+
+	// Clear removes all pairs from the cache without calling the eviction callback.
+	Clear()
+
+	// Get returns the value associated with the given key and marks the key as the most recently used one.
+	Get(k K) (V, bool)
+
+	// Has returns true if the given key is present in the cache.
+	Has(k K) bool
+
+	// Keys returns an iterator over all keys, from the least recently used to the most recently used one.
+	Keys() iter.Seq[K]
+
+	// Peek returns the value associated with the given key without changing the recency order.
+	Peek(k K) (V, bool)
+
+	// Remove removes the given key without calling the eviction callback.
+	Remove(k K)
+
+	// Resize changes the capacity of the cache, evicting the least recently used pairs if needed.
+	// It panics if capacity is less than 1.
+	Resize(capacity int)
+
+	// Set sets the value associated with the given key and marks the key as the most recently used one.
+	// If a new key is added to a full cache, the least recently used pair is evicted.
+	Set(k K, v V)
+}
+
+type comfyLRU[K comparable, V any] struct {
+	l        *comfyList[Pair[K, V]]
+	m        map[K]*comfyListNode[Pair[K, V]]
+	capacity int
+	onEvict  func(Pair[K, V])
+}
+
+// NewLRU creates a new LRU instance with the given capacity.
+// The onEvict callback, if not nil, is called with every pair evicted because the cache ran out of capacity.
+// It panics if capacity is less than 1.
+func NewLRU[K comparable, V any](capacity int, onEvict func(Pair[K, V])) LRU[K, V] {
+	if capacity < 1 {
+		panic("NewLRU() requires a capacity of at least 1")
+	}
+	return &comfyLRU[K, V]{
+		l:        &comfyList[Pair[K, V]]{},
+		m:        make(map[K]*comfyListNode[Pair[K, V]]),
+		capacity: capacity,
+		onEvict:  onEvict,
+	}
+}
+
+func (c *comfyLRU[K, V]) Cap() int {
+	return c.capacity
+}
+
+func (c *comfyLRU[K, V]) Clear() {
+	c.l.Clear()
+	c.m = make(map[K]*comfyListNode[Pair[K, V]])
+}
+
+func (c *comfyLRU[K, V]) Get(k K) (V, bool) {
+	n, ok := c.m[k]
+	if !ok {
+		var v V
+		return v, false
+	}
+	c.l.moveToBack(n)
+	return n.v.Val(), true
+}
+
+func (c *comfyLRU[K, V]) Has(k K) bool {
+	_, ok := c.m[k]
+	return ok
+}
+
+func (c *comfyLRU[K, V]) IsEmpty() bool {
+	return c.l.IsEmpty()
+}
+
+func (c *comfyLRU[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for pair := range c.l.Values() {
+			if !yield(pair.Key()) {
+				break
+			}
+		}
+	}
+}
+
+func (c *comfyLRU[K, V]) Len() int {
+	return c.l.Len()
+}
+
+func (c *comfyLRU[K, V]) Peek(k K) (V, bool) {
+	n, ok := c.m[k]
+	if !ok {
+		var v V
+		return v, false
+	}
+	return n.v.Val(), true
+}
+
+func (c *comfyLRU[K, V]) Remove(k K) {
+	n, ok := c.m[k]
+	if !ok {
+		return
+	}
+	c.l.unlink(n)
+	delete(c.m, k)
+}
+
+func (c *comfyLRU[K, V]) Resize(capacity int) {
+	if capacity < 1 {
+		panic("Resize() requires a capacity of at least 1")
+	}
+	c.capacity = capacity
+	c.evict()
+}
+
+func (c *comfyLRU[K, V]) Set(k K, v V) {
+	if n, ok := c.m[k]; ok {
+		n.v.SetVal(v)
+		c.l.moveToBack(n)
+		return
+	}
+	c.m[k] = c.l.pushBack(NewPair(k, v))
+	c.evict()
+}
+
+func (c *comfyLRU[K, V]) Values() iter.Seq[Pair[K, V]] {
+	return c.l.Values()
+}
+
+func (c *comfyLRU[K, V]) ValuesRev() iter.Seq[Pair[K, V]] {
+	return c.l.ValuesRev()
+}
+
+// Private:
+
+//nolint:unused
+func (c *comfyLRU[K, V]) copy() baseInternal[Pair[K, V]] {
+	newLRU := &comfyLRU[K, V]{
+		l:        &comfyList[Pair[K, V]]{},
+		m:        make(map[K]*comfyListNode[Pair[K, V]], len(c.m)),
+		capacity: c.capacity,
+		onEvict:  c.onEvict,
+	}
+	for pair := range c.l.Values() {
+		newLRU.m[pair.Key()] = newLRU.l.pushBack(pair.copy())
+	}
+	return newLRU
+}
+
+// evict removes the least recently used pairs until the cache fits its capacity.
+func (c *comfyLRU[K, V]) evict() {
+	for c.l.Len() > c.capacity {
+		n := c.l.head
+		c.l.unlink(n)
+		delete(c.m, n.v.Key())
+		if c.onEvict != nil {
+			c.onEvict(n.v)
+		}
+	}
+}
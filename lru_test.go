@@ -0,0 +1,173 @@
+package coll
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestNewLRU(t *testing.T) {
+	c := NewLRU[string, int](2, nil)
+	if c == nil {
+		t.Fatal("NewLRU[string, int]() returned nil")
+	}
+	if c.Cap() != 2 || !c.IsEmpty() || c.Len() != 0 {
+		t.Errorf("NewLRU[string, int]() returned unexpected state: cap %d, len %d", c.Cap(), c.Len())
+	}
+
+	t.Run("NewLRU() with zero capacity panics", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("NewLRU(0) did not panic")
+			}
+		}()
+		NewLRU[string, int](0, nil)
+	})
+}
+
+func Test_comfyLRU_Set(t *testing.T) {
+	evicted := []string(nil)
+	c := NewLRU(3, func(p Pair[string, int]) {
+		evicted = append(evicted, p.Key())
+	})
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Set("c", 3)
+	c.Set("a", 10)
+	c.Set("d", 4)
+
+	if got := slices.Collect(c.Keys()); !reflect.DeepEqual(got, []string{"c", "a", "d"}) {
+		t.Errorf("Keys() = %v, want %v", got, []string{"c", "a", "d"})
+	}
+	if !reflect.DeepEqual(evicted, []string{"b"}) {
+		t.Errorf("evicted = %v, want %v", evicted, []string{"b"})
+	}
+	if v, _ := c.Peek("a"); v != 10 {
+		t.Errorf("Peek(a) = %v, want 10", v)
+	}
+}
+
+func Test_comfyLRU_GetPeek(t *testing.T) {
+	c := NewLRU[string, int](3, nil)
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Set("c", 3)
+
+	if v, ok := c.Peek("a"); !ok || v != 1 {
+		t.Errorf("Peek(a) = %v, %v, want 1, true", v, ok)
+	}
+	if got := slices.Collect(c.Keys()); !reflect.DeepEqual(got, []string{"a", "b", "c"}) {
+		t.Errorf("Peek() changed the recency order: %v", got)
+	}
+
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Errorf("Get(a) = %v, %v, want 1, true", v, ok)
+	}
+	if got := slices.Collect(c.Keys()); !reflect.DeepEqual(got, []string{"b", "c", "a"}) {
+		t.Errorf("Get() did not promote the key: %v", got)
+	}
+
+	if v, ok := c.Get("x"); ok || v != 0 {
+		t.Errorf("Get(x) = %v, %v, want 0, false", v, ok)
+	}
+	if v, ok := c.Peek("x"); ok || v != 0 {
+		t.Errorf("Peek(x) = %v, %v, want 0, false", v, ok)
+	}
+	if !c.Has("b") || c.Has("x") {
+		t.Error("Has() returned wrong result")
+	}
+}
+
+func Test_comfyLRU_Resize(t *testing.T) {
+	evicted := []string(nil)
+	c := NewLRU(4, func(p Pair[string, int]) {
+		evicted = append(evicted, p.Key())
+	})
+	for i, k := range []string{"a", "b", "c", "d"} {
+		c.Set(k, i)
+	}
+	c.Get("a")
+
+	c.Resize(2)
+	if got := slices.Collect(c.Keys()); !reflect.DeepEqual(got, []string{"d", "a"}) {
+		t.Errorf("Keys() after Resize() = %v, want %v", got, []string{"d", "a"})
+	}
+	if !reflect.DeepEqual(evicted, []string{"b", "c"}) {
+		t.Errorf("evicted = %v, want %v", evicted, []string{"b", "c"})
+	}
+
+	c.Resize(3)
+	c.Set("e", 5)
+	if c.Len() != 3 || c.Cap() != 3 {
+		t.Errorf("Len() = %d, Cap() = %d, want 3, 3", c.Len(), c.Cap())
+	}
+
+	t.Run("Resize() with zero capacity panics", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("Resize(0) did not panic")
+			}
+		}()
+		c.Resize(0)
+	})
+}
+
+func Test_comfyLRU_RemoveClear(t *testing.T) {
+	evictions := 0
+	c := NewLRU(3, func(Pair[string, int]) { evictions++ })
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Set("c", 3)
+
+	c.Remove("b")
+	c.Remove("x")
+	if got := slices.Collect(c.Keys()); !reflect.DeepEqual(got, []string{"a", "c"}) {
+		t.Errorf("Keys() after Remove() = %v", got)
+	}
+	c.Clear()
+	if !c.IsEmpty() || c.Has("a") {
+		t.Error("Clear() did not remove all pairs")
+	}
+	if evictions != 0 {
+		t.Errorf("Remove() and Clear() called the eviction callback %d times", evictions)
+	}
+}
+
+func Test_comfyLRU_Values(t *testing.T) {
+	c := NewLRU[string, int](3, nil)
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Set("c", 3)
+	c.Get("a")
+
+	keysOf := func(pairs []Pair[string, int]) []string {
+		keys := []string(nil)
+		for _, p := range pairs {
+			keys = append(keys, p.Key())
+		}
+		return keys
+	}
+	if got := keysOf(slices.Collect(c.Values())); !reflect.DeepEqual(got, []string{"b", "c", "a"}) {
+		t.Errorf("Values() = %v", got)
+	}
+	if got := keysOf(slices.Collect(c.ValuesRev())); !reflect.DeepEqual(got, []string{"a", "c", "b"}) {
+		t.Errorf("ValuesRev() = %v", got)
+	}
+}
+
+func Test_comfyLRU_copy(t *testing.T) {
+	c1 := NewLRU[string, int](2, nil)
+	c1.Set("a", 1)
+	c1.Set("b", 2)
+	c2 := Copy(c1)
+
+	c1.Set("a", 10)
+	c1.Set("c", 3)
+	if v, _ := c2.Peek("a"); v != 1 {
+		t.Errorf("copy() did not copy pairs, Peek(a) = %v", v)
+	}
+	if got := slices.Collect(c2.Keys()); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("copy() did not create a deep copy, Keys() = %v", got)
+	}
+}
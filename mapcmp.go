@@ -313,6 +313,27 @@ func (c *comfyCmpMap[K, V]) copy() baseInternal[Pair[K, V]] {
 	return newCm
 }
 
+// filter returns a new map with copies of the pairs that match the predicate, keeping their order.
+func (c *comfyCmpMap[K, V]) filter(predicate Predicate[Pair[K, V]]) baseInternal[Pair[K, V]] {
+	filtered := NewCmpMap[K, V]().(*comfyCmpMap[K, V])
+	for _, pair := range c.s {
+		if predicate(pair) {
+			filtered.set(pair.copy())
+		}
+	}
+	return filtered
+}
+
+// remap returns a new map with the mapped pairs. The mapper receives copies of the pairs.
+// If mapped pairs share a key, the last one wins at the position of the first one.
+func (c *comfyCmpMap[K, V]) remap(f Mapper[Pair[K, V]]) baseInternal[Pair[K, V]] {
+	mapped := NewCmpMap[K, V]().(*comfyCmpMap[K, V])
+	for _, pair := range c.s {
+		mapped.set(f(pair.copy()))
+	}
+	return mapped
+}
+
 func (c *comfyCmpMap[K, V]) set(pair Pair[K, V]) {
 	pos, exists := c.kp[pair.Key()]
 	if exists {
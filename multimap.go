@@ -0,0 +1,221 @@
+package coll
+
+import (
+	"iter"
+)
+
+// MultiMap is a map that can hold multiple values per key.
+// It preserves the insertion order of keys, and of values within each key.
+//
+// Values and ValuesRev yield every key-value combination as a new Pair, flattened in key order.
+// Len returns the total number of values, use KeyCount for the number of keys.
+type MultiMap[K comparable, V any] interface {
+	Ordered[Pair[K, V]]
+
+	// Add appends the given values to the values of the given key.
+	Add(k K, v ...V)
+
+	// Clear removes all keys and values.
+	Clear()
+
+	// Get returns the first value associated with the given key.
+	Get(k K) (V, bool)
+
+	// GetAll returns an iterator over all values associated with the given key.
+	// The iterator is empty if the key is not present.
+	GetAll(k K) iter.Seq[V]
+
+	// Has returns true if the given key is present in the map.
+	Has(k K) bool
+
+	// KeyCount returns the number of distinct keys in the map.
+	KeyCount() int
+
+	// Keys returns an iterator over all keys in the map.
+	Keys() iter.Seq[K]
+
+	// KeyValues returns an iterator over all key-value combinations in the map.
+	// A key is yielded once for each of its values.
+	KeyValues() iter.Seq2[K, V]
+
+	// Remove removes the given key with all its values.
+	// Returns the number of removed values.
+	Remove(k K) (count int)
+
+	// RemoveValue removes values of the given key that match the given predicate.
+	// The key is removed when no values are left.
+	// Returns the number of removed values.
+	RemoveValue(k K, predicate Predicate[V]) (count int)
+
+	// Set replaces all values of the given key. The key keeps its position if it is already present.
+	// Setting no values removes the key.
+	Set(k K, v ...V)
+}
+
+type comfyMultiMap[K comparable, V any] struct {
+	m    Map[K, Sequence[V]]
+	size int
+}
+
+// NewMultiMap creates a new MultiMap instance.
+func NewMultiMap[K comparable, V any]() MultiMap[K, V] {
+	return &comfyMultiMap[K, V]{
+		m: NewMap[K, Sequence[V]](),
+	}
+}
+
+// NewMultiMapFrom creates a new MultiMap instance from a slice of pairs.
+// Pairs with the same key are grouped under that key in the given order.
+func NewMultiMapFrom[K comparable, V any](s []Pair[K, V]) MultiMap[K, V] {
+	mm := NewMultiMap[K, V]()
+	for _, pair := range s {
+		mm.Add(pair.Key(), pair.Val())
+	}
+	return mm
+}
+
+func (c *comfyMultiMap[K, V]) Add(k K, v ...V) {
+	if len(v) == 0 {
+		return
+	}
+	seq, ok := c.m.Get(k)
+	if !ok {
+		seq = NewSequence[V]()
+		c.m.Set(k, seq)
+	}
+	seq.Append(v...)
+	c.size += len(v)
+}
+
+func (c *comfyMultiMap[K, V]) Clear() {
+	c.m.Clear()
+	c.size = 0
+}
+
+func (c *comfyMultiMap[K, V]) Get(k K) (V, bool) {
+	if seq, ok := c.m.Get(k); ok {
+		for v := range seq.Values() {
+			return v, true
+		}
+	}
+	var v V
+	return v, false
+}
+
+func (c *comfyMultiMap[K, V]) GetAll(k K) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		seq, ok := c.m.Get(k)
+		if !ok {
+			return
+		}
+		for v := range seq.Values() {
+			if !yield(v) {
+				break
+			}
+		}
+	}
+}
+
+func (c *comfyMultiMap[K, V]) Has(k K) bool {
+	return c.m.Has(k)
+}
+
+func (c *comfyMultiMap[K, V]) IsEmpty() bool {
+	return c.size == 0
+}
+
+func (c *comfyMultiMap[K, V]) KeyCount() int {
+	return c.m.Len()
+}
+
+func (c *comfyMultiMap[K, V]) Keys() iter.Seq[K] {
+	return c.m.Keys()
+}
+
+func (c *comfyMultiMap[K, V]) KeyValues() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, seq := range c.m.KeyValues() {
+			for v := range seq.Values() {
+				if !yield(k, v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (c *comfyMultiMap[K, V]) Len() int {
+	return c.size
+}
+
+func (c *comfyMultiMap[K, V]) Remove(k K) (count int) {
+	seq, ok := c.m.Get(k)
+	if !ok {
+		return 0
+	}
+	count = seq.Len()
+	c.m.Remove(k)
+	c.size -= count
+	return count
+}
+
+func (c *comfyMultiMap[K, V]) RemoveValue(k K, predicate Predicate[V]) (count int) {
+	seq, ok := c.m.Get(k)
+	if !ok {
+		return 0
+	}
+	count = seq.RemoveMatching(predicate)
+	c.size -= count
+	if seq.IsEmpty() {
+		c.m.Remove(k)
+	}
+	return count
+}
+
+func (c *comfyMultiMap[K, V]) Set(k K, v ...V) {
+	if len(v) == 0 {
+		c.Remove(k)
+		return
+	}
+	if seq, ok := c.m.Get(k); ok {
+		c.size -= seq.Len()
+		seq.Clear()
+	}
+	c.Add(k, v...)
+}
+
+func (c *comfyMultiMap[K, V]) Values() iter.Seq[Pair[K, V]] {
+	return func(yield func(Pair[K, V]) bool) {
+		for k, v := range c.KeyValues() {
+			if !yield(NewPair(k, v)) {
+				break
+			}
+		}
+	}
+}
+
+func (c *comfyMultiMap[K, V]) ValuesRev() iter.Seq[Pair[K, V]] {
+	return func(yield func(Pair[K, V]) bool) {
+		for pair := range c.m.ValuesRev() {
+			for v := range pair.Val().ValuesRev() {
+				if !yield(NewPair(pair.Key(), v)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Private:
+
+//nolint:unused
+func (c *comfyMultiMap[K, V]) copy() baseInternal[Pair[K, V]] {
+	newM := NewMap[K, Sequence[V]]()
+	for k, seq := range c.m.KeyValues() {
+		newM.Set(k, Copy(seq))
+	}
+	return &comfyMultiMap[K, V]{
+		m:    newM,
+		size: c.size,
+	}
+}
@@ -0,0 +1,203 @@
+package coll
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func multiMapKVs(m MultiMap[string, int]) [][2]any {
+	kvs := [][2]any(nil)
+	for k, v := range m.KeyValues() {
+		kvs = append(kvs, [2]any{k, v})
+	}
+	return kvs
+}
+
+func TestNewMultiMap(t *testing.T) {
+	m := NewMultiMap[string, int]()
+	if m == nil {
+		t.Fatal("NewMultiMap[string, int]() returned nil")
+	}
+	if !m.IsEmpty() || m.Len() != 0 || m.KeyCount() != 0 {
+		t.Error("NewMultiMap[string, int]() is not empty")
+	}
+}
+
+func TestNewMultiMapFrom(t *testing.T) {
+	m := NewMultiMapFrom([]Pair[string, int]{
+		NewPair("b", 1),
+		NewPair("a", 2),
+		NewPair("b", 3),
+	})
+	if got, want := multiMapKVs(m), [][2]any{{"b", 1}, {"b", 3}, {"a", 2}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("NewMultiMapFrom() = %v, want %v", got, want)
+	}
+}
+
+func Test_comfyMultiMap_Add(t *testing.T) {
+	m := NewMultiMap[string, int]()
+	m.Add("x", 1)
+	m.Add("y", 2, 3)
+	m.Add("x", 4)
+	m.Add("z")
+
+	if got, want := multiMapKVs(m), [][2]any{{"x", 1}, {"x", 4}, {"y", 2}, {"y", 3}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Add() resulted in: %v, but wanted %v", got, want)
+	}
+	if m.Len() != 4 || m.KeyCount() != 2 {
+		t.Errorf("Len() = %d, KeyCount() = %d, want 4, 2", m.Len(), m.KeyCount())
+	}
+	if m.Has("z") {
+		t.Error("Add() without values added the key")
+	}
+	if got := slices.Collect(m.Keys()); !reflect.DeepEqual(got, []string{"x", "y"}) {
+		t.Errorf("Keys() = %v", got)
+	}
+}
+
+func Test_comfyMultiMap_Get(t *testing.T) {
+	m := NewMultiMap[string, int]()
+	m.Add("x", 1, 2, 3)
+
+	cases := []struct {
+		name    string
+		key     string
+		wantAll []int
+		want    int
+		wantOk  bool
+	}{
+		{name: "existing key", key: "x", wantAll: []int{1, 2, 3}, want: 1, wantOk: true},
+		{name: "missing key", key: "y", wantAll: []int(nil), want: 0, wantOk: false},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := slices.Collect(m.GetAll(tt.key)); !reflect.DeepEqual(got, tt.wantAll) {
+				t.Errorf("GetAll(%q) = %v, want %v", tt.key, got, tt.wantAll)
+			}
+			if v, ok := m.Get(tt.key); v != tt.want || ok != tt.wantOk {
+				t.Errorf("Get(%q) = %v, %v, want %v, %v", tt.key, v, ok, tt.want, tt.wantOk)
+			}
+			if has := m.Has(tt.key); has != tt.wantOk {
+				t.Errorf("Has(%q) = %v, want %v", tt.key, has, tt.wantOk)
+			}
+		})
+	}
+
+	t.Run("GetAll() break", func(t *testing.T) {
+		for v := range m.GetAll("x") {
+			if v != 1 {
+				t.Errorf("GetAll() did not stop, got %v", v)
+			}
+			break
+		}
+	})
+}
+
+func Test_comfyMultiMap_Set(t *testing.T) {
+	m := NewMultiMap[string, int]()
+	m.Add("x", 1, 2)
+	m.Add("y", 3)
+
+	in := []int{7, 8, 9}
+	m.Set("x", in...)
+	in[0] = 0
+	if got, want := multiMapKVs(m), [][2]any{{"x", 7}, {"x", 8}, {"x", 9}, {"y", 3}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Set() resulted in: %v, but wanted %v", got, want)
+	}
+	if m.Len() != 4 {
+		t.Errorf("Len() after Set() = %d, want 4", m.Len())
+	}
+
+	m.Set("y")
+	if m.Has("y") || m.Len() != 3 {
+		t.Errorf("Set() without values did not remove the key, Len() = %d", m.Len())
+	}
+}
+
+func Test_comfyMultiMap_Remove(t *testing.T) {
+	m := NewMultiMap[string, int]()
+	m.Add("x", 1, 2, 3, 4)
+	m.Add("y", 5)
+
+	if count := m.RemoveValue("x", func(v int) bool { return v%2 == 0 }); count != 2 {
+		t.Errorf("RemoveValue() returned %d, want 2", count)
+	}
+	if count := m.RemoveValue("z", func(int) bool { return true }); count != 0 {
+		t.Errorf("RemoveValue() on missing key returned %d, want 0", count)
+	}
+	if count := m.RemoveValue("y", func(int) bool { return true }); count != 1 {
+		t.Errorf("RemoveValue() returned %d, want 1", count)
+	}
+	if m.Has("y") {
+		t.Error("RemoveValue() did not remove the key without values")
+	}
+	if got, want := multiMapKVs(m), [][2]any{{"x", 1}, {"x", 3}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("RemoveValue() resulted in: %v, but wanted %v", got, want)
+	}
+
+	if count := m.Remove("x"); count != 2 {
+		t.Errorf("Remove() returned %d, want 2", count)
+	}
+	if count := m.Remove("x"); count != 0 {
+		t.Errorf("Remove() on missing key returned %d, want 0", count)
+	}
+	if !m.IsEmpty() || m.KeyCount() != 0 {
+		t.Error("Remove() did not remove all values")
+	}
+
+	m.Add("a", 1)
+	m.Clear()
+	if !m.IsEmpty() || m.Has("a") {
+		t.Error("Clear() did not remove all values")
+	}
+}
+
+func Test_comfyMultiMap_Values(t *testing.T) {
+	m := NewMultiMap[string, int]()
+	m.Add("x", 1, 2)
+	m.Add("y", 3)
+
+	toKVs := func(pairs []Pair[string, int]) [][2]any {
+		kvs := [][2]any(nil)
+		for _, p := range pairs {
+			kvs = append(kvs, [2]any{p.Key(), p.Val()})
+		}
+		return kvs
+	}
+
+	if got, want := toKVs(slices.Collect(m.Values())), [][2]any{{"x", 1}, {"x", 2}, {"y", 3}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Values() = %v, want %v", got, want)
+	}
+	if got, want := toKVs(slices.Collect(m.ValuesRev())), [][2]any{{"y", 3}, {"x", 2}, {"x", 1}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ValuesRev() = %v, want %v", got, want)
+	}
+
+	t.Run("KeyValues() break", func(t *testing.T) {
+		count := 0
+		for range m.KeyValues() {
+			count++
+			if count == 2 {
+				break
+			}
+		}
+		if count != 2 {
+			t.Errorf("KeyValues() did not stop, count = %d", count)
+		}
+	})
+}
+
+func Test_comfyMultiMap_copy(t *testing.T) {
+	c1 := NewMultiMap[string, int]()
+	c1.Add("x", 1, 2)
+	c2 := Copy(c1)
+
+	if !reflect.DeepEqual(c1, c2) {
+		t.Errorf("Copy() = %v, want %v", c2, c1)
+	}
+	c1.Add("x", 3)
+	c1.Add("y", 4)
+	if got := slices.Collect(c2.GetAll("x")); !reflect.DeepEqual(got, []int{1, 2}) || c2.Has("y") || c2.Len() != 2 {
+		t.Errorf("copy() did not create a deep copy, GetAll(x) = %v", got)
+	}
+}
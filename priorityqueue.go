@@ -0,0 +1,231 @@
+package coll
+
+import (
+	"cmp"
+	"container/heap"
+	"iter"
+)
+
+// PriorityQueue is a collection that always yields its highest-priority element first.
+// Priority is decided by the Comparator given at construction: the element for which the comparator
+// returns a negative value when compared with another one has the higher priority.
+//
+// The queue is backed by a binary heap: Push, Pop, Update and Fix are O(log n), Peek is O(1).
+type PriorityQueue[V any] interface {
+	Base[V]
+
+	// Clear removes all elements from the queue. Handles of removed elements become stale.
+	Clear()
+
+	// Drain returns an iterator that removes and yields elements in priority order.
+	// Elements that were not yielded, because the iteration was stopped early, are left in the queue.
+	Drain() iter.Seq[V]
+
+	// Fix re-establishes the element's position after its value was changed in place,
+	// e.g. through a pointer.
+	// Returns ErrValueNotFound if the handle does not belong to an element of this queue.
+	Fix(h PriorityQueueHandle[V]) error
+
+	// Peek returns the highest-priority element without removing it.
+	// Returns ErrEmptyCollection if the queue is empty.
+	Peek() (V, error)
+
+	// Pop removes and returns the highest-priority element.
+	// Returns ErrEmptyCollection if the queue is empty.
+	Pop() (V, error)
+
+	// Push adds the given value to the queue.
+	// Returns a handle that can be used to update the value later.
+	Push(v V) PriorityQueueHandle[V]
+
+	// Update replaces the value of the element referenced by the handle and moves it to its new position.
+	// Returns ErrValueNotFound if the handle does not belong to an element of this queue.
+	Update(h PriorityQueueHandle[V], v V) error
+}
+
+// PriorityQueueHandle references an element pushed to a PriorityQueue.
+// A handle becomes stale once its element is popped or removed from the queue.
+type PriorityQueueHandle[V any] interface {
+	// Val returns the current value of the element.
+	Val() V
+
+	item() *priorityQueueItem[V]
+}
+
+type priorityQueueItem[V any] struct {
+	val   V
+	index int
+	owner *priorityHeap[V]
+}
+
+func (i *priorityQueueItem[V]) Val() V {
+	return i.val
+}
+
+func (i *priorityQueueItem[V]) item() *priorityQueueItem[V] {
+	return i
+}
+
+// priorityHeap implements heap.Interface.
+type priorityHeap[V any] struct {
+	items []*priorityQueueItem[V]
+	cmp   Comparator[V]
+}
+
+func (h *priorityHeap[V]) Len() int {
+	return len(h.items)
+}
+
+func (h *priorityHeap[V]) Less(i, j int) bool {
+	return h.cmp(h.items[i].val, h.items[j].val) < 0
+}
+
+func (h *priorityHeap[V]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.items[i].index = i
+	h.items[j].index = j
+}
+
+func (h *priorityHeap[V]) Push(x any) {
+	it := x.(*priorityQueueItem[V])
+	it.index = len(h.items)
+	it.owner = h
+	h.items = append(h.items, it)
+}
+
+func (h *priorityHeap[V]) Pop() any {
+	last := len(h.items) - 1
+	it := h.items[last]
+	h.items[last] = nil
+	h.items = h.items[:last]
+	it.index = -1
+	it.owner = nil
+	return it
+}
+
+type comfyPriorityQueue[V any] struct {
+	h *priorityHeap[V]
+}
+
+// NewPriorityQueue creates a new PriorityQueue instance that orders elements with the given comparator.
+func NewPriorityQueue[V any](cmp Comparator[V]) PriorityQueue[V] {
+	return &comfyPriorityQueue[V]{
+		h: &priorityHeap[V]{cmp: cmp},
+	}
+}
+
+// NewCmpPriorityQueue creates a new PriorityQueue instance for cmp.Ordered values.
+// The smallest value has the highest priority, unless desc is true.
+func NewCmpPriorityQueue[V cmp.Ordered](desc bool) PriorityQueue[V] {
+	if desc {
+		return NewPriorityQueue(func(a, b V) int {
+			return cmp.Compare(b, a)
+		})
+	}
+	return NewPriorityQueue(cmp.Compare[V])
+}
+
+func (c *comfyPriorityQueue[V]) Clear() {
+	for _, it := range c.h.items {
+		it.index = -1
+		it.owner = nil
+	}
+	c.h.items = nil
+}
+
+func (c *comfyPriorityQueue[V]) Drain() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for c.h.Len() > 0 {
+			it := heap.Pop(c.h).(*priorityQueueItem[V])
+			if !yield(it.val) {
+				break
+			}
+		}
+	}
+}
+
+func (c *comfyPriorityQueue[V]) Fix(h PriorityQueueHandle[V]) error {
+	it, err := c.lookup(h)
+	if err != nil {
+		return err
+	}
+	heap.Fix(c.h, it.index)
+	return nil
+}
+
+func (c *comfyPriorityQueue[V]) IsEmpty() bool {
+	return c.h.Len() == 0
+}
+
+func (c *comfyPriorityQueue[V]) Len() int {
+	return c.h.Len()
+}
+
+func (c *comfyPriorityQueue[V]) Peek() (V, error) {
+	if c.h.Len() == 0 {
+		var v V
+		return v, ErrEmptyCollection
+	}
+	return c.h.items[0].val, nil
+}
+
+func (c *comfyPriorityQueue[V]) Pop() (V, error) {
+	if c.h.Len() == 0 {
+		var v V
+		return v, ErrEmptyCollection
+	}
+	return heap.Pop(c.h).(*priorityQueueItem[V]).val, nil
+}
+
+func (c *comfyPriorityQueue[V]) Push(v V) PriorityQueueHandle[V] {
+	it := &priorityQueueItem[V]{val: v}
+	heap.Push(c.h, it)
+	return it
+}
+
+func (c *comfyPriorityQueue[V]) Update(h PriorityQueueHandle[V], v V) error {
+	it, err := c.lookup(h)
+	if err != nil {
+		return err
+	}
+	it.val = v
+	heap.Fix(c.h, it.index)
+	return nil
+}
+
+// Values returns an iterator over all elements in the queue in no particular order.
+func (c *comfyPriorityQueue[V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, it := range c.h.items {
+			if !yield(it.val) {
+				break
+			}
+		}
+	}
+}
+
+// Private:
+
+//nolint:unused
+func (c *comfyPriorityQueue[V]) copy() baseInternal[V] {
+	newHeap := &priorityHeap[V]{
+		items: make([]*priorityQueueItem[V], len(c.h.items)),
+		cmp:   c.h.cmp,
+	}
+	for i, it := range c.h.items {
+		newHeap.items[i] = &priorityQueueItem[V]{val: it.val, index: i, owner: newHeap}
+	}
+	return &comfyPriorityQueue[V]{h: newHeap}
+}
+
+// lookup returns the item referenced by the handle if it belongs to this queue.
+func (c *comfyPriorityQueue[V]) lookup(h PriorityQueueHandle[V]) (*priorityQueueItem[V], error) {
+	if h == nil {
+		return nil, ErrValueNotFound
+	}
+	it := h.item()
+	if it == nil || it.owner != c.h {
+		return nil, ErrValueNotFound
+	}
+	return it, nil
+}
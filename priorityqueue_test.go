@@ -0,0 +1,204 @@
+package coll
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestNewPriorityQueue(t *testing.T) {
+	pq := NewPriorityQueue(func(a, b string) int { return len(a) - len(b) })
+	if pq == nil {
+		t.Fatal("NewPriorityQueue[string]() returned nil")
+	}
+	pq.Push("ccc")
+	pq.Push("a")
+	pq.Push("bb")
+	if got := slices.Collect(pq.Drain()); !reflect.DeepEqual(got, []string{"a", "bb", "ccc"}) {
+		t.Errorf("Drain() = %v, want %v", got, []string{"a", "bb", "ccc"})
+	}
+}
+
+func TestNewCmpPriorityQueue(t *testing.T) {
+	cases := []struct {
+		name string
+		desc bool
+		want []int
+	}{
+		{name: "NewCmpPriorityQueue(false)", desc: false, want: []int{1, 2, 3, 4, 5}},
+		{name: "NewCmpPriorityQueue(true)", desc: true, want: []int{5, 4, 3, 2, 1}},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			pq := NewCmpPriorityQueue[int](tt.desc)
+			for _, v := range []int{3, 1, 4, 5, 2} {
+				pq.Push(v)
+			}
+			if got := slices.Collect(pq.Drain()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Drain() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_comfyPriorityQueue_PushPop(t *testing.T) {
+	rnd := rand.New(rand.NewSource(42))
+	pq := NewCmpPriorityQueue[int](false)
+	want := make([]int, 0, 200)
+	for range 200 {
+		v := rnd.Intn(1000)
+		pq.Push(v)
+		want = append(want, v)
+	}
+	slices.Sort(want)
+
+	if pq.Len() != 200 || pq.IsEmpty() {
+		t.Fatalf("Len() = %d, IsEmpty() = %v", pq.Len(), pq.IsEmpty())
+	}
+	for i, w := range want {
+		if v, err := pq.Peek(); err != nil || v != w {
+			t.Fatalf("Peek() #%d = %v, %v, want %v, nil", i, v, err, w)
+		}
+		if v, err := pq.Pop(); err != nil || v != w {
+			t.Fatalf("Pop() #%d = %v, %v, want %v, nil", i, v, err, w)
+		}
+	}
+	if _, err := pq.Pop(); !errors.Is(err, ErrEmptyCollection) {
+		t.Errorf("Pop() on empty queue returned error %v, want ErrEmptyCollection", err)
+	}
+	if _, err := pq.Peek(); !errors.Is(err, ErrEmptyCollection) {
+		t.Errorf("Peek() on empty queue returned error %v, want ErrEmptyCollection", err)
+	}
+}
+
+func Test_comfyPriorityQueue_Values(t *testing.T) {
+	pq := NewCmpPriorityQueue[int](false)
+	for _, v := range []int{5, 3, 8, 1} {
+		pq.Push(v)
+	}
+	got := slices.Sorted(pq.Values())
+	if !reflect.DeepEqual(got, []int{1, 3, 5, 8}) {
+		t.Errorf("Values() = %v, want %v in any order", got, []int{1, 3, 5, 8})
+	}
+	if pq.Len() != 4 {
+		t.Errorf("Values() modified the queue, Len() = %d", pq.Len())
+	}
+}
+
+func Test_comfyPriorityQueue_Drain_break(t *testing.T) {
+	pq := NewCmpPriorityQueue[int](false)
+	for _, v := range []int{5, 3, 8, 1} {
+		pq.Push(v)
+	}
+	got := []int(nil)
+	for v := range pq.Drain() {
+		got = append(got, v)
+		if v == 3 {
+			break
+		}
+	}
+	if !reflect.DeepEqual(got, []int{1, 3}) {
+		t.Errorf("Drain() with break = %v, want %v", got, []int{1, 3})
+	}
+	if rest := slices.Collect(pq.Drain()); !reflect.DeepEqual(rest, []int{5, 8}) {
+		t.Errorf("Drain() after break = %v, want %v", rest, []int{5, 8})
+	}
+}
+
+func Test_comfyPriorityQueue_Update(t *testing.T) {
+	pq := NewCmpPriorityQueue[int](false)
+	h10 := pq.Push(10)
+	pq.Push(20)
+	h30 := pq.Push(30)
+
+	if err := pq.Update(h30, 5); err != nil {
+		t.Fatalf("Update() returned error %v", err)
+	}
+	if h30.Val() != 5 {
+		t.Errorf("Val() after Update() = %v, want 5", h30.Val())
+	}
+	if err := pq.Update(h10, 25); err != nil {
+		t.Fatalf("Update() returned error %v", err)
+	}
+	if got := slices.Collect(pq.Drain()); !reflect.DeepEqual(got, []int{5, 20, 25}) {
+		t.Errorf("Drain() after Update() = %v, want %v", got, []int{5, 20, 25})
+	}
+}
+
+func Test_comfyPriorityQueue_Fix(t *testing.T) {
+	type task struct {
+		name     string
+		priority int
+	}
+	pq := NewPriorityQueue(func(a, b *task) int { return a.priority - b.priority })
+	a := &task{name: "a", priority: 1}
+	b := &task{name: "b", priority: 2}
+	c := &task{name: "c", priority: 3}
+	pq.Push(a)
+	pq.Push(b)
+	hc := pq.Push(c)
+
+	c.priority = 0
+	if err := pq.Fix(hc); err != nil {
+		t.Fatalf("Fix() returned error %v", err)
+	}
+	if v, _ := pq.Peek(); v != c {
+		t.Errorf("Peek() after Fix() = %v, want %v", v.name, c.name)
+	}
+}
+
+func Test_comfyPriorityQueue_staleHandle(t *testing.T) {
+	pq := NewCmpPriorityQueue[int](false)
+	other := NewCmpPriorityQueue[int](false)
+	h1 := pq.Push(1)
+	h2 := pq.Push(2)
+	hOther := other.Push(3)
+
+	_, _ = pq.Pop()
+	cases := []struct {
+		name string
+		err  error
+	}{
+		{name: "Update() with popped handle", err: pq.Update(h1, 0)},
+		{name: "Fix() with popped handle", err: pq.Fix(h1)},
+		{name: "Update() with foreign handle", err: pq.Update(hOther, 0)},
+		{name: "Fix() with nil handle", err: pq.Fix(nil)},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if !errors.Is(tt.err, ErrValueNotFound) {
+				t.Errorf("%s returned error %v, want ErrValueNotFound", tt.name, tt.err)
+			}
+		})
+	}
+
+	pq.Clear()
+	if !pq.IsEmpty() {
+		t.Error("IsEmpty() = false after Clear()")
+	}
+	if err := pq.Update(h2, 0); !errors.Is(err, ErrValueNotFound) {
+		t.Errorf("Update() after Clear() returned error %v, want ErrValueNotFound", err)
+	}
+}
+
+func Test_comfyPriorityQueue_copy(t *testing.T) {
+	c1 := NewCmpPriorityQueue[int](false)
+	h := c1.Push(2)
+	c1.Push(1)
+	c1.Push(3)
+	c2 := Copy(c1)
+
+	if err := c2.Update(h, 0); !errors.Is(err, ErrValueNotFound) {
+		t.Errorf("Update() on copy with original handle returned error %v, want ErrValueNotFound", err)
+	}
+	_ = c1.Update(h, 0)
+	c1.Push(-1)
+	if got := slices.Collect(c2.Drain()); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Errorf("copy() did not create a deep copy, got %v", got)
+	}
+	if c1.Len() != 4 {
+		t.Errorf("draining the copy modified the original, Len() = %d", c1.Len())
+	}
+}
@@ -0,0 +1,362 @@
+package coll
+
+import (
+	"cmp"
+	"iter"
+)
+
+// Interval is a half-open range [Lo, Hi) of ordered keys.
+type Interval[K cmp.Ordered] struct {
+	Lo K
+	Hi K
+}
+
+// Contains returns true if the given key lies within the interval.
+func (i Interval[K]) Contains(k K) bool {
+	return i.Lo <= k && k < i.Hi
+}
+
+// IsEmpty returns true if the interval contains no keys.
+func (i Interval[K]) IsEmpty() bool {
+	return i.Lo >= i.Hi
+}
+
+// Overlaps returns true if both intervals have at least one key in common.
+func (i Interval[K]) Overlaps(other Interval[K]) bool {
+	return i.Lo < other.Hi && other.Lo < i.Hi && !i.IsEmpty() && !other.IsEmpty()
+}
+
+// RangeMap maps half-open intervals of keys to values.
+//
+// The maps created by NewRangeMap and NewCoalescingRangeMap keep their ranges disjoint, so that every key
+// is mapped to at most one value: putting a value over a range that overlaps existing ones replaces
+// the overlapped parts, splitting or trimming the existing ranges as needed.
+// The map created by NewOverlappingRangeMap keeps overlapping ranges side by side instead,
+// so that a key can be covered by several ranges, for example by several schedule windows.
+//
+// Ranges are iterated in ascending order of Lo, then Hi, then insertion, as pairs of an Interval and its value.
+// Yielded pairs are copies: changing them does not change the map.
+type RangeMap[K cmp.Ordered, V any] interface {
+	Ordered[Pair[Interval[K], V]]
+
+	// Clear removes all ranges.
+	Clear()
+
+	// Containing returns an iterator over the ranges that contain the given point, in ascending order.
+	Containing(point K) iter.Seq[Pair[Interval[K], V]]
+
+	// Get returns the value of the range that contains the given point.
+	// If several ranges contain it, the value of the first one in ascending order is returned.
+	Get(point K) (V, bool)
+
+	// GetRange returns the range that contains the given point, together with its value.
+	// If several ranges contain it, the first one in ascending order is returned.
+	GetRange(point K) (Pair[Interval[K], V], bool)
+
+	// Overlapping returns an iterator over the ranges that overlap the half-open range [lo, hi), in ascending order.
+	// The ranges are returned whole, not clipped to [lo, hi).
+	Overlapping(lo, hi K) iter.Seq[Pair[Interval[K], V]]
+
+	// Put maps all keys in the half-open range [lo, hi) to the given value.
+	// In an overlapping RangeMap it adds a new range, even if an equal one is already present.
+	// It does nothing if the range is empty.
+	Put(lo, hi K, v V)
+
+	// Remove unmaps all keys in the half-open range [lo, hi), trimming or splitting the ranges that stick out of it.
+	Remove(lo, hi K)
+}
+
+type rangeMapEntry[K cmp.Ordered, V any] struct {
+	hi  K
+	val V
+}
+
+type comfyRangeMap[K cmp.Ordered, V any] struct {
+	t     sortedTree[K, rangeMapEntry[K, V]]
+	equal func(a, b V) bool
+}
+
+// NewRangeMap creates a new RangeMap instance.
+func NewRangeMap[K cmp.Ordered, V any]() RangeMap[K, V] {
+	return &comfyRangeMap[K, V]{}
+}
+
+// NewCoalescingRangeMap creates a new RangeMap instance that merges adjacent ranges mapped to equal values,
+// so that for example putting [1, 3) and [3, 5) with the same value results in a single range [1, 5).
+func NewCoalescingRangeMap[K cmp.Ordered, V comparable]() RangeMap[K, V] {
+	return &comfyRangeMap[K, V]{
+		equal: func(a, b V) bool {
+			return a == b
+		},
+	}
+}
+
+func (c *comfyRangeMap[K, V]) Clear() {
+	c.t.clear()
+}
+
+func (c *comfyRangeMap[K, V]) Containing(point K) iter.Seq[Pair[Interval[K], V]] {
+	return func(yield func(Pair[Interval[K], V]) bool) {
+		if n := c.nodeAt(point); n != nil {
+			yield(c.pairOf(n))
+		}
+	}
+}
+
+func (c *comfyRangeMap[K, V]) Get(point K) (V, bool) {
+	n := c.nodeAt(point)
+	if n == nil {
+		var v V
+		return v, false
+	}
+	return n.val.val, true
+}
+
+func (c *comfyRangeMap[K, V]) GetRange(point K) (Pair[Interval[K], V], bool) {
+	n := c.nodeAt(point)
+	if n == nil {
+		return nil, false
+	}
+	return c.pairOf(n), true
+}
+
+func (c *comfyRangeMap[K, V]) IsEmpty() bool {
+	return c.t.len() == 0
+}
+
+func (c *comfyRangeMap[K, V]) Len() int {
+	return c.t.len()
+}
+
+func (c *comfyRangeMap[K, V]) Overlapping(lo, hi K) iter.Seq[Pair[Interval[K], V]] {
+	return func(yield func(Pair[Interval[K], V]) bool) {
+		if lo >= hi {
+			return
+		}
+		from := lo
+		if n := c.t.floor(lo); n != nil && n.val.hi > lo {
+			from = n.key
+		}
+		c.t.ascendFrom(from, func(n *sortedTreeNode[K, rangeMapEntry[K, V]]) bool {
+			return cmp.Less(n.key, hi) && yield(c.pairOf(n))
+		})
+	}
+}
+
+func (c *comfyRangeMap[K, V]) Put(lo, hi K, v V) {
+	if lo >= hi {
+		return
+	}
+	c.cut(lo, hi)
+	c.t.put(lo, rangeMapEntry[K, V]{hi: hi, val: v})
+	if c.equal == nil {
+		return
+	}
+
+	if left := c.t.lower(lo); left != nil && left.val.hi == lo && c.equal(left.val.val, v) {
+		c.t.delete(lo)
+		left.val.hi = hi
+		lo = left.key
+	}
+	if right := c.t.get(hi); right != nil && c.equal(right.val.val, v) {
+		c.t.delete(hi)
+		c.t.get(lo).val.hi = right.val.hi
+	}
+}
+
+func (c *comfyRangeMap[K, V]) Remove(lo, hi K) {
+	if lo >= hi {
+		return
+	}
+	c.cut(lo, hi)
+}
+
+func (c *comfyRangeMap[K, V]) Values() iter.Seq[Pair[Interval[K], V]] {
+	return func(yield func(Pair[Interval[K], V]) bool) {
+		c.t.ascend(func(n *sortedTreeNode[K, rangeMapEntry[K, V]]) bool {
+			return yield(c.pairOf(n))
+		})
+	}
+}
+
+func (c *comfyRangeMap[K, V]) ValuesRev() iter.Seq[Pair[Interval[K], V]] {
+	return func(yield func(Pair[Interval[K], V]) bool) {
+		c.t.descend(func(n *sortedTreeNode[K, rangeMapEntry[K, V]]) bool {
+			return yield(c.pairOf(n))
+		})
+	}
+}
+
+// Private:
+
+//nolint:unused
+func (c *comfyRangeMap[K, V]) copy() baseInternal[Pair[Interval[K], V]] {
+	return &comfyRangeMap[K, V]{
+		t: c.t.clone(func(e rangeMapEntry[K, V]) rangeMapEntry[K, V] {
+			return e
+		}),
+		equal: c.equal,
+	}
+}
+
+// cut unmaps the half-open range [lo, hi), trimming or splitting the ranges that stick out of it.
+func (c *comfyRangeMap[K, V]) cut(lo, hi K) {
+	if n := c.t.lower(lo); n != nil && n.val.hi > lo {
+		if n.val.hi > hi {
+			c.t.put(hi, n.val)
+		}
+		n.val.hi = lo
+	}
+	for {
+		n := c.t.ceiling(lo)
+		if n == nil || n.key >= hi {
+			return
+		}
+		if n.val.hi > hi {
+			c.t.put(hi, n.val)
+		}
+		c.t.delete(n.key)
+	}
+}
+
+// nodeAt returns the node of the range that contains the given point or nil if there is no such range.
+func (c *comfyRangeMap[K, V]) nodeAt(point K) *sortedTreeNode[K, rangeMapEntry[K, V]] {
+	n := c.t.floor(point)
+	if n == nil || n.val.hi <= point {
+		return nil
+	}
+	return n
+}
+
+func (c *comfyRangeMap[K, V]) pairOf(n *sortedTreeNode[K, rangeMapEntry[K, V]]) Pair[Interval[K], V] {
+	return NewPair(Interval[K]{Lo: n.key, Hi: n.val.hi}, n.val.val)
+}
+
+type comfyOverlappingRangeMap[K cmp.Ordered, V any] struct {
+	t intervalTree[K, V]
+}
+
+// NewOverlappingRangeMap creates a new RangeMap instance that keeps overlapping ranges side by side
+// instead of replacing the overlapped parts.
+// Finding the ranges that contain a point or overlap a range takes O(log n + m) for m results.
+func NewOverlappingRangeMap[K cmp.Ordered, V any]() RangeMap[K, V] {
+	return &comfyOverlappingRangeMap[K, V]{}
+}
+
+func (c *comfyOverlappingRangeMap[K, V]) Clear() {
+	c.t.clear()
+}
+
+func (c *comfyOverlappingRangeMap[K, V]) Containing(point K) iter.Seq[Pair[Interval[K], V]] {
+	return func(yield func(Pair[Interval[K], V]) bool) {
+		c.containing(point, func(n *intervalTreeNode[K, V]) bool {
+			return yield(c.pairOf(n))
+		})
+	}
+}
+
+func (c *comfyOverlappingRangeMap[K, V]) Get(point K) (V, bool) {
+	n := c.nodeAt(point)
+	if n == nil {
+		var v V
+		return v, false
+	}
+	return n.val, true
+}
+
+func (c *comfyOverlappingRangeMap[K, V]) GetRange(point K) (Pair[Interval[K], V], bool) {
+	n := c.nodeAt(point)
+	if n == nil {
+		return nil, false
+	}
+	return c.pairOf(n), true
+}
+
+func (c *comfyOverlappingRangeMap[K, V]) IsEmpty() bool {
+	return c.t.len() == 0
+}
+
+func (c *comfyOverlappingRangeMap[K, V]) Len() int {
+	return c.t.len()
+}
+
+func (c *comfyOverlappingRangeMap[K, V]) Overlapping(lo, hi K) iter.Seq[Pair[Interval[K], V]] {
+	return func(yield func(Pair[Interval[K], V]) bool) {
+		if lo >= hi {
+			return
+		}
+		c.t.overlapping(lo, func(nLo K) bool { return nLo < hi }, func(n *intervalTreeNode[K, V]) bool {
+			return yield(c.pairOf(n))
+		})
+	}
+}
+
+func (c *comfyOverlappingRangeMap[K, V]) Put(lo, hi K, v V) {
+	if lo >= hi {
+		return
+	}
+	c.t.insert(lo, hi, v)
+}
+
+func (c *comfyOverlappingRangeMap[K, V]) Remove(lo, hi K) {
+	if lo >= hi {
+		return
+	}
+	overlapping := []*intervalTreeNode[K, V](nil)
+	c.t.overlapping(lo, func(nLo K) bool { return nLo < hi }, func(n *intervalTreeNode[K, V]) bool {
+		overlapping = append(overlapping, n)
+		return true
+	})
+
+	for _, n := range overlapping {
+		c.t.delete(n)
+		// The parts that stick out keep the id of the range, and with it its position among equal ranges.
+		if n.lo < lo {
+			c.t.insertNode(&intervalTreeNode[K, V]{lo: n.lo, hi: lo, id: n.id, val: n.val})
+		}
+		if n.hi > hi {
+			c.t.insertNode(&intervalTreeNode[K, V]{lo: hi, hi: n.hi, id: n.id, val: n.val})
+		}
+	}
+}
+
+func (c *comfyOverlappingRangeMap[K, V]) Values() iter.Seq[Pair[Interval[K], V]] {
+	return func(yield func(Pair[Interval[K], V]) bool) {
+		c.t.ascend(func(n *intervalTreeNode[K, V]) bool {
+			return yield(c.pairOf(n))
+		})
+	}
+}
+
+func (c *comfyOverlappingRangeMap[K, V]) ValuesRev() iter.Seq[Pair[Interval[K], V]] {
+	return func(yield func(Pair[Interval[K], V]) bool) {
+		c.t.descend(func(n *intervalTreeNode[K, V]) bool {
+			return yield(c.pairOf(n))
+		})
+	}
+}
+
+// Private:
+
+//nolint:unused
+func (c *comfyOverlappingRangeMap[K, V]) copy() baseInternal[Pair[Interval[K], V]] {
+	return &comfyOverlappingRangeMap[K, V]{t: c.t.clone()}
+}
+
+// containing visits the nodes of the ranges that contain the given point, in ascending order.
+func (c *comfyOverlappingRangeMap[K, V]) containing(point K, visit func(n *intervalTreeNode[K, V]) bool) {
+	c.t.overlapping(point, func(nLo K) bool { return nLo <= point }, visit)
+}
+
+// nodeAt returns the node of the first range that contains the given point or nil if there is no such range.
+func (c *comfyOverlappingRangeMap[K, V]) nodeAt(point K) (found *intervalTreeNode[K, V]) {
+	c.containing(point, func(n *intervalTreeNode[K, V]) bool {
+		found = n
+		return false
+	})
+	return found
+}
+
+func (c *comfyOverlappingRangeMap[K, V]) pairOf(n *intervalTreeNode[K, V]) Pair[Interval[K], V] {
+	return NewPair(Interval[K]{Lo: n.lo, Hi: n.hi}, n.val)
+}
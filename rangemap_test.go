@@ -0,0 +1,496 @@
+package coll
+
+import (
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+type rangeMapTestRange struct {
+	lo, hi int
+	v      string
+}
+
+func rangeMapRanges(seq func(func(Pair[Interval[int], string]) bool)) []rangeMapTestRange {
+	ranges := []rangeMapTestRange(nil)
+	for p := range seq {
+		ranges = append(ranges, rangeMapTestRange{lo: p.Key().Lo, hi: p.Key().Hi, v: p.Val()})
+	}
+	return ranges
+}
+
+func TestInterval(t *testing.T) {
+	i := Interval[int]{Lo: 2, Hi: 5}
+	if !i.Contains(2) || !i.Contains(4) || i.Contains(5) || i.Contains(1) {
+		t.Error("Contains() does not treat the interval as half-open")
+	}
+	if i.IsEmpty() || !(Interval[int]{Lo: 3, Hi: 3}).IsEmpty() || !(Interval[int]{Lo: 4, Hi: 3}).IsEmpty() {
+		t.Error("IsEmpty() returned wrong result")
+	}
+
+	cases := []struct {
+		other Interval[int]
+		want  bool
+	}{
+		{other: Interval[int]{Lo: 0, Hi: 2}, want: false},
+		{other: Interval[int]{Lo: 0, Hi: 3}, want: true},
+		{other: Interval[int]{Lo: 3, Hi: 4}, want: true},
+		{other: Interval[int]{Lo: 4, Hi: 9}, want: true},
+		{other: Interval[int]{Lo: 5, Hi: 9}, want: false},
+		{other: Interval[int]{Lo: 3, Hi: 3}, want: false},
+	}
+	for _, tt := range cases {
+		if got := i.Overlaps(tt.other); got != tt.want {
+			t.Errorf("Overlaps(%v) = %v, want %v", tt.other, got, tt.want)
+		}
+		if got := tt.other.Overlaps(i); got != tt.want {
+			t.Errorf("reversed Overlaps(%v) = %v, want %v", tt.other, got, tt.want)
+		}
+	}
+}
+
+func TestNewRangeMap(t *testing.T) {
+	rm := NewRangeMap[int, string]()
+	if rm == nil {
+		t.Fatal("NewRangeMap[int, string]() returned nil")
+	}
+	if !reflect.DeepEqual(rm, &comfyRangeMap[int, string]{}) {
+		t.Error("NewRangeMap[int, string]() did not return an empty comfyRangeMap[int, string]")
+	}
+	if !rm.IsEmpty() || rm.Len() != 0 {
+		t.Error("NewRangeMap() is not empty")
+	}
+}
+
+func Test_comfyRangeMap_Put(t *testing.T) {
+	cases := []struct {
+		name       string
+		coalescing bool
+		puts       []rangeMapTestRange
+		want       []rangeMapTestRange
+	}{
+		{
+			name: "Put() disjoint ranges",
+			puts: []rangeMapTestRange{{10, 20, "b"}, {0, 5, "a"}, {30, 40, "c"}},
+			want: []rangeMapTestRange{{0, 5, "a"}, {10, 20, "b"}, {30, 40, "c"}},
+		},
+		{
+			name: "Put() empty range is ignored",
+			puts: []rangeMapTestRange{{0, 5, "a"}, {3, 3, "x"}, {4, 2, "x"}},
+			want: []rangeMapTestRange{{0, 5, "a"}},
+		},
+		{
+			name: "Put() splits an enclosing range",
+			puts: []rangeMapTestRange{{0, 10, "a"}, {3, 6, "b"}},
+			want: []rangeMapTestRange{{0, 3, "a"}, {3, 6, "b"}, {6, 10, "a"}},
+		},
+		{
+			name: "Put() trims overlapped ranges and replaces covered ones",
+			puts: []rangeMapTestRange{{0, 5, "a"}, {5, 8, "b"}, {8, 12, "c"}, {3, 10, "x"}},
+			want: []rangeMapTestRange{{0, 3, "a"}, {3, 10, "x"}, {10, 12, "c"}},
+		},
+		{
+			name: "Put() same range replaces the value",
+			puts: []rangeMapTestRange{{0, 5, "a"}, {0, 5, "b"}},
+			want: []rangeMapTestRange{{0, 5, "b"}},
+		},
+		{
+			name: "Put() adjacent equal ranges are kept apart without coalescing",
+			puts: []rangeMapTestRange{{0, 5, "a"}, {5, 10, "a"}},
+			want: []rangeMapTestRange{{0, 5, "a"}, {5, 10, "a"}},
+		},
+		{
+			name:       "Put() coalesces with left and right neighbours",
+			coalescing: true,
+			puts:       []rangeMapTestRange{{0, 5, "a"}, {10, 15, "a"}, {5, 10, "a"}},
+			want:       []rangeMapTestRange{{0, 15, "a"}},
+		},
+		{
+			name:       "Put() does not coalesce different values or gaps",
+			coalescing: true,
+			puts:       []rangeMapTestRange{{0, 5, "a"}, {5, 10, "b"}, {11, 15, "b"}},
+			want:       []rangeMapTestRange{{0, 5, "a"}, {5, 10, "b"}, {11, 15, "b"}},
+		},
+		{
+			name:       "Put() coalesces overlapping equal values",
+			coalescing: true,
+			puts:       []rangeMapTestRange{{0, 10, "a"}, {5, 15, "a"}},
+			want:       []rangeMapTestRange{{0, 15, "a"}},
+		},
+		{
+			name:       "Put() inside an equal range leaves it whole",
+			coalescing: true,
+			puts:       []rangeMapTestRange{{0, 10, "a"}, {3, 6, "a"}},
+			want:       []rangeMapTestRange{{0, 10, "a"}},
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			rm := NewRangeMap[int, string]()
+			if tt.coalescing {
+				rm = NewCoalescingRangeMap[int, string]()
+			}
+			for _, r := range tt.puts {
+				rm.Put(r.lo, r.hi, r.v)
+			}
+			if got := rangeMapRanges(rm.Values()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Put() resulted in: %v, but wanted %v", got, tt.want)
+			}
+			if rm.Len() != len(tt.want) {
+				t.Errorf("Len() = %d, want %d", rm.Len(), len(tt.want))
+			}
+		})
+	}
+}
+
+func Test_comfyRangeMap_Remove(t *testing.T) {
+	rm := NewRangeMap[int, string]()
+	rm.Put(0, 10, "a")
+	rm.Put(10, 20, "b")
+	rm.Put(30, 40, "c")
+
+	rm.Remove(5, 12)
+	rm.Remove(32, 35)
+	rm.Remove(50, 40)
+	want := []rangeMapTestRange{{0, 5, "a"}, {12, 20, "b"}, {30, 32, "c"}, {35, 40, "c"}}
+	if got := rangeMapRanges(rm.Values()); !reflect.DeepEqual(got, want) {
+		t.Errorf("Remove() resulted in: %v, but wanted %v", got, want)
+	}
+
+	rm.Remove(-100, 100)
+	if !rm.IsEmpty() {
+		t.Errorf("Remove() of everything left: %v", rangeMapRanges(rm.Values()))
+	}
+}
+
+func Test_comfyRangeMap_Get(t *testing.T) {
+	rm := NewRangeMap[int, string]()
+	rm.Put(0, 10, "a")
+	rm.Put(20, 30, "b")
+
+	cases := []struct {
+		point  int
+		want   string
+		wantOk bool
+	}{
+		{point: -1, wantOk: false},
+		{point: 0, want: "a", wantOk: true},
+		{point: 9, want: "a", wantOk: true},
+		{point: 10, wantOk: false},
+		{point: 25, want: "b", wantOk: true},
+		{point: 30, wantOk: false},
+	}
+	for _, tt := range cases {
+		if v, ok := rm.Get(tt.point); v != tt.want || ok != tt.wantOk {
+			t.Errorf("Get(%d) = %q, %v, want %q, %v", tt.point, v, ok, tt.want, tt.wantOk)
+		}
+	}
+
+	if p, ok := rm.GetRange(25); !ok || p.Key() != (Interval[int]{Lo: 20, Hi: 30}) || p.Val() != "b" {
+		t.Errorf("GetRange(25) = %v, %v", p, ok)
+	}
+	if p, ok := rm.GetRange(15); ok || p != nil {
+		t.Errorf("GetRange(15) = %v, %v, want nil, false", p, ok)
+	}
+}
+
+func Test_comfyRangeMap_Overlapping(t *testing.T) {
+	rm := NewRangeMap[int, string]()
+	rm.Put(0, 10, "a")
+	rm.Put(10, 20, "b")
+	rm.Put(30, 40, "c")
+
+	cases := []struct {
+		name   string
+		lo, hi int
+		want   []rangeMapTestRange
+	}{
+		{name: "inside one range", lo: 3, hi: 5, want: []rangeMapTestRange{{0, 10, "a"}}},
+		{name: "across a boundary", lo: 5, hi: 15, want: []rangeMapTestRange{{0, 10, "a"}, {10, 20, "b"}}},
+		{name: "touching ends are excluded", lo: 20, hi: 30, want: nil},
+		{name: "whole map", lo: -5, hi: 100, want: []rangeMapTestRange{{0, 10, "a"}, {10, 20, "b"}, {30, 40, "c"}}},
+		{name: "gap and range", lo: 25, hi: 31, want: []rangeMapTestRange{{30, 40, "c"}}},
+		{name: "empty query", lo: 5, hi: 5, want: nil},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rangeMapRanges(rm.Overlapping(tt.lo, tt.hi)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Overlapping(%d, %d) = %v, want %v", tt.lo, tt.hi, got, tt.want)
+			}
+		})
+	}
+
+	count := 0
+	for range rm.Overlapping(-5, 100) {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Errorf("Overlapping() did not stop, count = %d", count)
+	}
+}
+
+func Test_comfyRangeMap_randomOperations(t *testing.T) {
+	const size = 60
+	rnd := rand.New(rand.NewSource(42))
+	for _, coalescing := range []bool{false, true} {
+		rm := NewRangeMap[int, string]()
+		if coalescing {
+			rm = NewCoalescingRangeMap[int, string]()
+		}
+		reference := make([]string, size)
+
+		for range 500 {
+			lo := rnd.Intn(size)
+			hi := lo + rnd.Intn(size-lo) + 1
+			v := ""
+			if rnd.Intn(4) > 0 {
+				v = []string{"a", "b", "c"}[rnd.Intn(3)]
+			}
+			if v == "" {
+				rm.Remove(lo, hi)
+			} else {
+				rm.Put(lo, hi, v)
+			}
+			for i := lo; i < hi; i++ {
+				reference[i] = v
+			}
+		}
+
+		for point, want := range reference {
+			if got, ok := rm.Get(point); got != want || ok != (want != "") {
+				t.Fatalf("Get(%d) = %q, %v, want %q", point, got, ok, want)
+			}
+		}
+		ranges := rangeMapRanges(rm.Values())
+		for i := 1; i < len(ranges); i++ {
+			prev, cur := ranges[i-1], ranges[i]
+			if prev.hi > cur.lo {
+				t.Fatalf("ranges %v and %v overlap", prev, cur)
+			}
+			if coalescing && prev.hi == cur.lo && prev.v == cur.v {
+				t.Fatalf("ranges %v and %v were not coalesced", prev, cur)
+			}
+		}
+	}
+}
+
+func Test_comfyRangeMap_ValuesRev(t *testing.T) {
+	rm := NewRangeMap[int, string]()
+	rm.Put(0, 1, "a")
+	rm.Put(1, 2, "b")
+	rm.Put(5, 6, "c")
+
+	values := []string(nil)
+	for p := range rm.ValuesRev() {
+		values = append(values, p.Val())
+	}
+	if !slices.Equal(values, []string{"c", "b", "a"}) {
+		t.Errorf("ValuesRev() = %v", values)
+	}
+}
+
+func Test_comfyRangeMap_yieldedPairsAreCopies(t *testing.T) {
+	rm := NewRangeMap[int, string]()
+	rm.Put(0, 5, "a")
+	for p := range rm.Values() {
+		p.SetVal("x")
+	}
+	if v, _ := rm.Get(0); v != "a" {
+		t.Errorf("changing a yielded pair changed the map: Get(0) = %q", v)
+	}
+}
+
+func Test_comfyRangeMap_copy(t *testing.T) {
+	c1 := NewRangeMap[int, string]()
+	c1.Put(0, 10, "a")
+	c1.Put(20, 30, "b")
+	c2 := Copy(c1)
+
+	if !reflect.DeepEqual(c1, c2) {
+		t.Errorf("Copy() = %v, want %v", c2, c1)
+	}
+	c1.Put(5, 25, "x")
+	if got := rangeMapRanges(c2.Values()); !reflect.DeepEqual(got, []rangeMapTestRange{{0, 10, "a"}, {20, 30, "b"}}) {
+		t.Errorf("copy() did not create a deep copy: %v", got)
+	}
+
+	c3 := Copy(NewCoalescingRangeMap[int, string]())
+	c3.Put(0, 5, "a")
+	c3.Put(5, 10, "a")
+	if c3.Len() != 1 {
+		t.Error("copy() of a coalescing map does not coalesce")
+	}
+}
+
+func Test_comfyRangeMap_Containing(t *testing.T) {
+	rm := NewRangeMap[int, string]()
+	rm.Put(0, 10, "a")
+	rm.Put(5, 15, "b")
+
+	if got := rangeMapRanges(rm.Containing(7)); !reflect.DeepEqual(got, []rangeMapTestRange{{5, 15, "b"}}) {
+		t.Errorf("Containing(7) = %v", got)
+	}
+	if got := rangeMapRanges(rm.Containing(15)); got != nil {
+		t.Errorf("Containing(15) = %v, want none", got)
+	}
+}
+
+func TestNewOverlappingRangeMap(t *testing.T) {
+	rm := NewOverlappingRangeMap[int, string]()
+	if rm == nil {
+		t.Fatal("NewOverlappingRangeMap[int, string]() returned nil")
+	}
+	if !reflect.DeepEqual(rm, &comfyOverlappingRangeMap[int, string]{}) {
+		t.Error("NewOverlappingRangeMap[int, string]() did not return an empty comfyOverlappingRangeMap[int, string]")
+	}
+	if !rm.IsEmpty() || rm.Len() != 0 {
+		t.Error("NewOverlappingRangeMap() is not empty")
+	}
+}
+
+func Test_comfyOverlappingRangeMap_Put(t *testing.T) {
+	rm := NewOverlappingRangeMap[int, string]()
+	rm.Put(9, 17, "standup")
+	rm.Put(12, 13, "lunch")
+	rm.Put(9, 17, "on call")
+	rm.Put(8, 10, "commute")
+	rm.Put(5, 5, "empty")
+
+	want := []rangeMapTestRange{{8, 10, "commute"}, {9, 17, "standup"}, {9, 17, "on call"}, {12, 13, "lunch"}}
+	if got := rangeMapRanges(rm.Values()); !reflect.DeepEqual(got, want) {
+		t.Errorf("Values() = %v, want %v", got, want)
+	}
+	if rm.Len() != 4 {
+		t.Errorf("Len() = %d, want 4", rm.Len())
+	}
+	slices.Reverse(want)
+	if got := rangeMapRanges(rm.ValuesRev()); !reflect.DeepEqual(got, want) {
+		t.Errorf("ValuesRev() = %v, want %v", got, want)
+	}
+}
+
+func Test_comfyOverlappingRangeMap_queries(t *testing.T) {
+	rm := NewOverlappingRangeMap[int, string]()
+	rm.Put(0, 10, "a")
+	rm.Put(5, 15, "b")
+	rm.Put(5, 8, "c")
+	rm.Put(20, 30, "d")
+
+	cases := []struct {
+		point int
+		want  []rangeMapTestRange
+	}{
+		{point: -1, want: nil},
+		{point: 0, want: []rangeMapTestRange{{0, 10, "a"}}},
+		{point: 6, want: []rangeMapTestRange{{0, 10, "a"}, {5, 8, "c"}, {5, 15, "b"}}},
+		{point: 10, want: []rangeMapTestRange{{5, 15, "b"}}},
+		{point: 15, want: nil},
+	}
+	for _, tt := range cases {
+		if got := rangeMapRanges(rm.Containing(tt.point)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Containing(%d) = %v, want %v", tt.point, got, tt.want)
+		}
+		v, ok := rm.Get(tt.point)
+		p, pOk := rm.GetRange(tt.point)
+		if tt.want == nil {
+			if ok || pOk || v != "" || p != nil {
+				t.Errorf("Get(%d) = %q, %v and GetRange(%d) = %v, %v, want nothing", tt.point, v, ok, tt.point, p, pOk)
+			}
+			continue
+		}
+		if !ok || v != tt.want[0].v {
+			t.Errorf("Get(%d) = %q, %v, want %q", tt.point, v, ok, tt.want[0].v)
+		}
+		if !pOk || p.Key() != (Interval[int]{Lo: tt.want[0].lo, Hi: tt.want[0].hi}) {
+			t.Errorf("GetRange(%d) = %v, %v", tt.point, p, pOk)
+		}
+	}
+
+	overlapping := []struct {
+		lo, hi int
+		want   []rangeMapTestRange
+	}{
+		{lo: 12, hi: 25, want: []rangeMapTestRange{{5, 15, "b"}, {20, 30, "d"}}},
+		{lo: 7, hi: 21, want: []rangeMapTestRange{{0, 10, "a"}, {5, 8, "c"}, {5, 15, "b"}, {20, 30, "d"}}},
+		{lo: 15, hi: 20, want: nil},
+		{lo: 6, hi: 6, want: nil},
+	}
+	for _, tt := range overlapping {
+		if got := rangeMapRanges(rm.Overlapping(tt.lo, tt.hi)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Overlapping(%d, %d) = %v, want %v", tt.lo, tt.hi, got, tt.want)
+		}
+	}
+}
+
+func Test_comfyOverlappingRangeMap_Remove(t *testing.T) {
+	rm := NewOverlappingRangeMap[int, string]()
+	rm.Put(0, 10, "a")
+	rm.Put(0, 10, "b")
+	rm.Put(4, 6, "c")
+	rm.Put(20, 30, "d")
+
+	rm.Remove(3, 7)
+	rm.Remove(25, 40)
+	rm.Remove(9, 9)
+	want := []rangeMapTestRange{{0, 3, "a"}, {0, 3, "b"}, {7, 10, "a"}, {7, 10, "b"}, {20, 25, "d"}}
+	if got := rangeMapRanges(rm.Values()); !reflect.DeepEqual(got, want) {
+		t.Errorf("Remove() resulted in: %v, but wanted %v", got, want)
+	}
+
+	rm.Clear()
+	if !rm.IsEmpty() {
+		t.Error("Clear() did not remove all ranges")
+	}
+}
+
+func Test_comfyOverlappingRangeMap_randomOperations(t *testing.T) {
+	const size = 40
+	rnd := rand.New(rand.NewSource(42))
+	rm := NewOverlappingRangeMap[int, int]()
+	reference := make([][]int, size)
+
+	for i := range 300 {
+		lo := rnd.Intn(size)
+		hi := lo + rnd.Intn(size-lo) + 1
+		if rnd.Intn(4) == 0 {
+			rm.Remove(lo, hi)
+			for p := lo; p < hi; p++ {
+				reference[p] = nil
+			}
+			continue
+		}
+		rm.Put(lo, hi, i)
+		for p := lo; p < hi; p++ {
+			reference[p] = append(reference[p], i)
+		}
+	}
+
+	for point, want := range reference {
+		got := []int(nil)
+		for p := range rm.Containing(point) {
+			got = append(got, p.Val())
+		}
+		slices.Sort(got)
+		if !slices.Equal(got, want) {
+			t.Fatalf("Containing(%d) = %v, want %v", point, got, want)
+		}
+	}
+}
+
+func Test_comfyOverlappingRangeMap_copy(t *testing.T) {
+	c1 := NewOverlappingRangeMap[int, string]()
+	c1.Put(0, 10, "a")
+	c1.Put(5, 15, "b")
+	c2 := Copy(c1)
+
+	if !reflect.DeepEqual(c1, c2) {
+		t.Errorf("Copy() = %v, want %v", c2, c1)
+	}
+	c1.Put(0, 10, "x")
+	c1.Remove(0, 100)
+	if got := rangeMapRanges(c2.Values()); !reflect.DeepEqual(got, []rangeMapTestRange{{0, 10, "a"}, {5, 15, "b"}}) {
+		t.Errorf("copy() did not create a deep copy: %v", got)
+	}
+}
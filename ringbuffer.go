@@ -0,0 +1,179 @@
+package coll
+
+import (
+	"iter"
+	"slices"
+)
+
+// OverflowPolicy defines what a bounded collection does when a value is added while it is full.
+type OverflowPolicy int
+
+const (
+	// OverflowOverwrite makes the collection drop its oldest element to make room for the new one.
+	OverflowOverwrite OverflowPolicy = iota
+
+	// OverflowReject makes the collection refuse the write with ErrCollectionFull.
+	OverflowReject
+)
+
+// RingBuffer is a fixed-capacity collection that keeps elements in insertion order,
+// from the oldest to the newest.
+// What happens when the buffer is full is decided by the OverflowPolicy given at construction.
+type RingBuffer[V any] interface {
+	Indexed[V]
+
+	// Cap returns the maximum number of elements the buffer can hold.
+	Cap() int
+
+	// Clear removes all elements from the buffer.
+	Clear()
+
+	// IsFull returns true if the buffer holds Cap() elements.
+	IsFull() bool
+
+	// Peek returns the oldest element without removing it.
+	// Returns ErrEmptyCollection if the buffer is empty.
+	Peek() (V, error)
+
+	// Pop removes and returns the oldest element.
+	// Returns ErrEmptyCollection if the buffer is empty.
+	Pop() (V, error)
+
+	// Push adds the given values at the end of the buffer.
+	// With OverflowOverwrite the oldest elements are dropped to make room and the error is always nil.
+	// With OverflowReject ErrCollectionFull is returned and nothing is written
+	// if the buffer cannot take all the given values.
+	Push(v ...V) error
+}
+
+type comfyRingBuffer[V any] struct {
+	buf    []V
+	head   int
+	size   int
+	policy OverflowPolicy
+}
+
+// NewRingBuffer creates a new RingBuffer instance with the given capacity and overflow policy.
+// It panics if capacity is less than 1.
+func NewRingBuffer[V any](capacity int, policy OverflowPolicy) RingBuffer[V] {
+	if capacity < 1 {
+		panic("NewRingBuffer() requires a capacity of at least 1")
+	}
+	return &comfyRingBuffer[V]{
+		buf:    make([]V, capacity),
+		policy: policy,
+	}
+}
+
+func (c *comfyRingBuffer[V]) At(i int) (V, bool) {
+	if i < 0 || i >= c.size {
+		var v V
+		return v, false
+	}
+	return c.buf[c.pos(i)], true
+}
+
+func (c *comfyRingBuffer[V]) AtOrDefault(i int, defaultValue V) V {
+	if i < 0 || i >= c.size {
+		return defaultValue
+	}
+	return c.buf[c.pos(i)]
+}
+
+func (c *comfyRingBuffer[V]) Cap() int {
+	return len(c.buf)
+}
+
+func (c *comfyRingBuffer[V]) Clear() {
+	clear(c.buf)
+	c.head = 0
+	c.size = 0
+}
+
+func (c *comfyRingBuffer[V]) IsEmpty() bool {
+	return c.size == 0
+}
+
+func (c *comfyRingBuffer[V]) IsFull() bool {
+	return c.size == len(c.buf)
+}
+
+func (c *comfyRingBuffer[V]) Len() int {
+	return c.size
+}
+
+func (c *comfyRingBuffer[V]) Peek() (V, error) {
+	if c.size == 0 {
+		var v V
+		return v, ErrEmptyCollection
+	}
+	return c.buf[c.head], nil
+}
+
+func (c *comfyRingBuffer[V]) Pop() (V, error) {
+	if c.size == 0 {
+		var v V
+		return v, ErrEmptyCollection
+	}
+	v := c.buf[c.head]
+	var zero V
+	c.buf[c.head] = zero
+	c.head = c.pos(1)
+	c.size--
+	return v, nil
+}
+
+func (c *comfyRingBuffer[V]) Push(v ...V) error {
+	if c.policy == OverflowReject && c.size+len(v) > len(c.buf) {
+		return ErrCollectionFull
+	}
+
+	for _, v := range v {
+		if c.IsFull() {
+			c.buf[c.head] = v
+			c.head = c.pos(1)
+			continue
+		}
+		c.buf[c.pos(c.size)] = v
+		c.size++
+	}
+
+	return nil
+}
+
+func (c *comfyRingBuffer[V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for i := range c.size {
+			if !yield(c.buf[c.pos(i)]) {
+				break
+			}
+		}
+	}
+}
+
+func (c *comfyRingBuffer[V]) ValuesRev() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for i := c.size - 1; i >= 0; i-- {
+			if !yield(c.buf[c.pos(i)]) {
+				break
+			}
+		}
+	}
+}
+
+// Private:
+
+//nolint:unused
+func (c *comfyRingBuffer[V]) copy() baseInternal[V] {
+	return &comfyRingBuffer[V]{
+		buf:    slices.Clone(c.buf),
+		head:   c.head,
+		size:   c.size,
+		policy: c.policy,
+	}
+}
+
+// pos translates a logical index into a position in the underlying buffer.
+func (c *comfyRingBuffer[V]) pos(i int) int {
+	return (c.head + i) % len(c.buf)
+}
@@ -0,0 +1,218 @@
+package coll
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+type comfyRingBufferIntBuilder[C any] struct {
+}
+
+func (lcb *comfyRingBufferIntBuilder[C]) Empty() C {
+	return any(lcb.make([]int(nil))).(C)
+}
+
+func (lcb *comfyRingBufferIntBuilder[C]) One() C {
+	return any(lcb.make([]int{111})).(C)
+}
+
+func (lcb *comfyRingBufferIntBuilder[C]) Two() C {
+	return any(lcb.make([]int{123, 234})).(C)
+}
+
+func (lcb *comfyRingBufferIntBuilder[C]) Three() C {
+	return any(lcb.make([]int{111, 222, 333})).(C)
+}
+
+func (lcb *comfyRingBufferIntBuilder[C]) ThreeRev() C {
+	return any(lcb.make([]int{333, 222, 111})).(C)
+}
+
+func (lcb *comfyRingBufferIntBuilder[C]) SixWithDuplicates() C {
+	return any(lcb.make([]int{111, 222, 333, 111, 222, 333})).(C)
+}
+
+func (lcb *comfyRingBufferIntBuilder[C]) FromValues(values []any) C {
+	c := lcb.make([]int{})
+	for _, v := range values {
+		_ = c.Push(v.(int))
+	}
+	return any(c).(C)
+}
+
+// make builds a buffer whose contents wrap around the end of the underlying slice,
+// so that the shared test cases also exercise index translation.
+func (lcb *comfyRingBufferIntBuilder[C]) make(items []int) *comfyRingBuffer[int] {
+	coll := NewRingBuffer[int](8, OverflowOverwrite).(*comfyRingBuffer[int])
+	_ = coll.Push(0, 0, 0, 0, 0, 0, 0)
+	for !coll.IsEmpty() {
+		_, _ = coll.Pop()
+	}
+	_ = coll.Push(items...)
+
+	return coll
+}
+
+func (lcb *comfyRingBufferIntBuilder[C]) extractRawValues(c C) any {
+	return lcb.extractUnderlyingSlice(c)
+}
+
+func (lcb *comfyRingBufferIntBuilder[C]) extractUnderlyingSlice(c C) any {
+	return slices.Collect((any(c)).(*comfyRingBuffer[int]).Values())
+}
+
+func (lcb *comfyRingBufferIntBuilder[C]) extractUnderlyingMap(_ C) any {
+	return nil
+}
+
+func (lcb *comfyRingBufferIntBuilder[C]) extractUnderlyingKp(_ C) any {
+	return nil
+}
+
+func (lcb *comfyRingBufferIntBuilder[C]) extractUnderlyingValsCount(_ C) any {
+	return nil
+}
+
+func TestNewRingBuffer(t *testing.T) {
+	rb := NewRingBuffer[int](3, OverflowReject)
+	if rb == nil {
+		t.Error("NewRingBuffer[int]() returned nil")
+	}
+	if rb.Cap() != 3 || rb.Len() != 0 || !rb.IsEmpty() || rb.IsFull() {
+		t.Errorf("NewRingBuffer[int]() returned unexpected state: cap %d, len %d", rb.Cap(), rb.Len())
+	}
+
+	t.Run("NewRingBuffer() with zero capacity panics", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("NewRingBuffer(0) did not panic")
+			}
+		}()
+		NewRingBuffer[int](0, OverflowOverwrite)
+	})
+}
+
+func Test_comfyRingBuffer_At(t *testing.T) {
+	testAt(t, &comfyRingBufferIntBuilder[indexedInternal[int]]{})
+}
+
+func Test_comfyRingBuffer_AtOrDefault(t *testing.T) {
+	testAtOrDefault(t, &comfyRingBufferIntBuilder[indexedInternal[int]]{})
+}
+
+func Test_comfyRingBuffer_IsEmpty(t *testing.T) {
+	testIsEmpty(t, &comfyRingBufferIntBuilder[baseInternal[int]]{})
+}
+
+func Test_comfyRingBuffer_Len(t *testing.T) {
+	testLen(t, &comfyRingBufferIntBuilder[baseInternal[int]]{})
+}
+
+func Test_comfyRingBuffer_Values(t *testing.T) {
+	testValues(t, &comfyRingBufferIntBuilder[baseInternal[int]]{})
+	testValuesBreak(t, &comfyRingBufferIntBuilder[baseInternal[int]]{})
+}
+
+func Test_comfyRingBuffer_ValuesRev(t *testing.T) {
+	testValuesRev(t, &comfyRingBufferIntBuilder[orderedInternal[int]]{})
+	testValuesRevBreak(t, &comfyRingBufferIntBuilder[orderedInternal[int]]{})
+}
+
+func Test_comfyRingBuffer_copy(t *testing.T) {
+	testCopy(t, &comfyRingBufferIntBuilder[baseInternal[int]]{})
+}
+
+func Test_comfyRingBuffer_copy_pointer(t *testing.T) {
+	c1 := NewRingBuffer[int](3, OverflowOverwrite)
+	_ = c1.Push(1, 2, 3)
+	c2 := Copy(c1)
+
+	_ = c1.Push(4)
+	if got := slices.Collect(c2.Values()); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Errorf("copy() did not create a deep copy, got %v", got)
+	}
+}
+
+func Test_comfyRingBuffer_Push_overwrite(t *testing.T) {
+	rb := NewRingBuffer[int](3, OverflowOverwrite)
+	cases := []struct {
+		name string
+		push []int
+		want []int
+	}{
+		{name: "Push() below capacity", push: []int{1, 2}, want: []int{1, 2}},
+		{name: "Push() up to capacity", push: []int{3}, want: []int{1, 2, 3}},
+		{name: "Push() overwrites oldest", push: []int{4}, want: []int{2, 3, 4}},
+		{name: "Push() more than capacity", push: []int{5, 6, 7, 8}, want: []int{6, 7, 8}},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := rb.Push(tt.push...); err != nil {
+				t.Errorf("Push() returned error %v", err)
+			}
+			if got := slices.Collect(rb.Values()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Push() resulted in: %v, but wanted %v", got, tt.want)
+			}
+		})
+	}
+	if !rb.IsFull() {
+		t.Error("IsFull() = false on a full buffer")
+	}
+}
+
+func Test_comfyRingBuffer_Push_reject(t *testing.T) {
+	rb := NewRingBuffer[int](3, OverflowReject)
+	if err := rb.Push(1, 2); err != nil {
+		t.Fatalf("Push() returned error %v", err)
+	}
+	if err := rb.Push(3, 4); !errors.Is(err, ErrCollectionFull) {
+		t.Errorf("Push() returned error %v, want ErrCollectionFull", err)
+	}
+	if got := slices.Collect(rb.Values()); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Errorf("rejected Push() modified the buffer: %v", got)
+	}
+	if err := rb.Push(3); err != nil {
+		t.Errorf("Push() returned error %v", err)
+	}
+	if err := rb.Push(4); !errors.Is(err, ErrCollectionFull) {
+		t.Errorf("Push() on full buffer returned error %v, want ErrCollectionFull", err)
+	}
+}
+
+func Test_comfyRingBuffer_PeekPop(t *testing.T) {
+	rb := NewRingBuffer[int](2, OverflowOverwrite)
+	if _, err := rb.Peek(); !errors.Is(err, ErrEmptyCollection) {
+		t.Errorf("Peek() on empty buffer returned error %v, want ErrEmptyCollection", err)
+	}
+	if _, err := rb.Pop(); !errors.Is(err, ErrEmptyCollection) {
+		t.Errorf("Pop() on empty buffer returned error %v, want ErrEmptyCollection", err)
+	}
+
+	_ = rb.Push(1, 2, 3)
+	if v, err := rb.Peek(); err != nil || v != 2 {
+		t.Errorf("Peek() = %v, %v, want 2, nil", v, err)
+	}
+	if v, err := rb.Pop(); err != nil || v != 2 {
+		t.Errorf("Pop() = %v, %v, want 2, nil", v, err)
+	}
+	if v, err := rb.Pop(); err != nil || v != 3 {
+		t.Errorf("Pop() = %v, %v, want 3, nil", v, err)
+	}
+	if !rb.IsEmpty() {
+		t.Error("IsEmpty() = false after popping all values")
+	}
+}
+
+func Test_comfyRingBuffer_Clear(t *testing.T) {
+	rb := NewRingBuffer[int](3, OverflowReject)
+	_ = rb.Push(1, 2, 3)
+	rb.Clear()
+	if !rb.IsEmpty() || rb.Cap() != 3 {
+		t.Errorf("Clear() resulted in len %d, cap %d", rb.Len(), rb.Cap())
+	}
+	if err := rb.Push(4, 5, 6); err != nil {
+		t.Errorf("Push() after Clear() returned error %v", err)
+	}
+}
@@ -137,3 +137,28 @@ func (c *comfySeq[V]) copy() baseInternal[V] {
 
 	return newCl
 }
+
+func (c *comfySeq[V]) filter(predicate Predicate[V]) baseInternal[V] {
+	filtered := &comfySeq[V]{
+		s: []V(nil),
+	}
+	for _, v := range c.s {
+		if predicate(v) {
+			filtered.s = append(filtered.s, v)
+		}
+	}
+	return filtered
+}
+
+func (c *comfySeq[V]) remap(f Mapper[V]) baseInternal[V] {
+	mapped := &comfySeq[V]{
+		s: []V(nil),
+	}
+	if len(c.s) > 0 {
+		mapped.s = make([]V, len(c.s))
+	}
+	for i, v := range c.s {
+		mapped.s[i] = f(v)
+	}
+	return mapped
+}
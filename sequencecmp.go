@@ -248,3 +248,21 @@ func (c *comfyCmpSeq[V]) copy() baseInternal[V] {
 	ccl.Append(c.s...)
 	return ccl
 }
+
+func (c *comfyCmpSeq[V]) filter(predicate Predicate[V]) baseInternal[V] {
+	filtered := NewCmpSequence[V]().(*comfyCmpSeq[V])
+	for _, v := range c.s {
+		if predicate(v) {
+			filtered.Append(v)
+		}
+	}
+	return filtered
+}
+
+func (c *comfyCmpSeq[V]) remap(f Mapper[V]) baseInternal[V] {
+	mapped := NewCmpSequence[V]().(*comfyCmpSeq[V])
+	for _, v := range c.s {
+		mapped.Append(f(v))
+	}
+	return mapped
+}
@@ -1,63 +1,236 @@
 package coll
 
 import (
-	"cmp"
+	"iter"
 )
 
-// Set is a collection of unique elements.
-type Set[K comparable, V cmp.Ordered] interface {
-	CmpMap[K, V]
-}
-
-//type comfySet[K comparable, V cmp.Cmp] struct {
-//	m       map[K]*kvPair[K, V]
-//	l       []*kvPair[K, V]
-//	revVals map[V]bool
-//	mutex   sync.Mutex
-//}
-
-//func NewSet[K comparable, V cmp.Cmp]() Set[K, V] {
-//	return &Set[K, V]{
-//		m:       make(map[K]*kvPair[K, V]),
-//		l:       make([]*kvPair[K, V], 0),
-//		revVals: make(map[V]bool),
-//	}
-//}
-//
-//func NewSetFrom[K comparable, V cmp.Cmp](m map[K]V) Set[K, V] {
-//	s := NewSet[K, V]()
-//	for k, v := range m {
-//		s.set(k, v)
-//	}
-//
-//	return s
-//}
-//
-//func (om *Set[K, V]) set(it *kvPair[K, V]) {
-//	if _, ok := om.revVals[it.v]; !ok {
-//		om.revVals[it.v] = true
-//		om.comfyMap.set(it)
-//	}
-//}
-//
-//func (cs *comfySet[K, V]) set(it *kvPair[K, V]) {
-//	if _, ok := ccm.revVals[it.v]; !ok {
-//		ccm.revVals[it.v] = true
-//		ccm.cm.set(it)
-//	}
-//}
-//
-//func (cs *comfySet[K, V]) remove(k K) {
-//	if _, ok := ccm.cm.m[k]; !ok {
-//		return
-//	}
-//
-//	ccm.cm.remove(k)
-//
-//	for _, current := range ccm.cm.l {
-//		if current.k == k {
-//			delete(ccm.revVals, current.v)
-//			return
-//		}
-//	}
-//}
+// Set is an insertion-ordered collection of unique elements.
+type Set[V comparable] interface {
+	Ordered[V]
+	Mutable[V]
+
+	// Add adds the given values to the set.
+	// Values that are already present keep their original position.
+	Add(v ...V)
+
+	// Contains returns true if the set contains the given value.
+	Contains(v V) bool
+
+	// Difference returns a new set with the values of this set that are not present in the other set.
+	Difference(other Set[V]) Set[V]
+
+	// Intersection returns a new set with the values of this set that are also present in the other set.
+	Intersection(other Set[V]) Set[V]
+
+	// IsSubsetOf returns true if all values of this set are present in the other set.
+	IsSubsetOf(other Set[V]) bool
+
+	// IsSupersetOf returns true if all values of the other set are present in this set.
+	IsSupersetOf(other Set[V]) bool
+
+	// Remove removes the given values from the set.
+	// Returns the number of removed items.
+	Remove(v ...V) (count int)
+
+	// SymmetricDifference returns a new set with the values that are present in exactly one of the sets.
+	// Values of this set come first, followed by the values of the other set.
+	SymmetricDifference(other Set[V]) Set[V]
+
+	// Union returns a new set with the values of this set followed by the values of the other set.
+	Union(other Set[V]) Set[V]
+}
+
+type comfySet[V comparable] struct {
+	s  []V
+	kp map[V]int
+}
+
+// NewSet creates a new Set instance.
+func NewSet[V comparable]() Set[V] {
+	return &comfySet[V]{
+		s:  []V(nil),
+		kp: make(map[V]int),
+	}
+}
+
+// NewSetFrom creates a new Set instance from a slice.
+// Duplicated values are added only once, at the position of their first occurrence.
+func NewSetFrom[V comparable](s []V) Set[V] {
+	cs := NewSet[V]()
+	cs.Add(s...)
+	return cs
+}
+
+func (c *comfySet[V]) Add(v ...V) {
+	for _, v := range v {
+		c.add(v)
+	}
+}
+
+func (c *comfySet[V]) Apply(f Mapper[V]) {
+	old := c.s
+	c.Clear()
+	for _, v := range old {
+		c.add(f(v))
+	}
+}
+
+func (c *comfySet[V]) Clear() {
+	c.s = []V(nil)
+	c.kp = make(map[V]int)
+}
+
+func (c *comfySet[V]) Contains(v V) bool {
+	_, ok := c.kp[v]
+	return ok
+}
+
+func (c *comfySet[V]) Difference(other Set[V]) Set[V] {
+	return c.filter(func(v V) bool {
+		return !other.Contains(v)
+	}).(Set[V])
+}
+
+func (c *comfySet[V]) Intersection(other Set[V]) Set[V] {
+	return c.filter(other.Contains).(Set[V])
+}
+
+func (c *comfySet[V]) IsEmpty() bool {
+	return len(c.s) == 0
+}
+
+func (c *comfySet[V]) IsSubsetOf(other Set[V]) bool {
+	if c.Len() > other.Len() {
+		return false
+	}
+	for _, v := range c.s {
+		if !other.Contains(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *comfySet[V]) IsSupersetOf(other Set[V]) bool {
+	if c.Len() < other.Len() {
+		return false
+	}
+	for v := range other.Values() {
+		if !c.Contains(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *comfySet[V]) Len() int {
+	return len(c.s)
+}
+
+func (c *comfySet[V]) Remove(v ...V) (count int) {
+	toRemove := make(map[V]struct{}, len(v))
+	for _, v := range v {
+		if c.Contains(v) {
+			toRemove[v] = struct{}{}
+		}
+	}
+
+	if len(toRemove) == 0 {
+		return 0
+	}
+
+	return c.RemoveMatching(func(v V) bool {
+		_, ok := toRemove[v]
+		return ok
+	})
+}
+
+func (c *comfySet[V]) RemoveMatching(predicate Predicate[V]) (count int) {
+	c.s, count = sliceRemoveMatching(c.s, predicate)
+	if count > 0 {
+		c.kp = comfyMakeKeyPosMap(c.s)
+	}
+	return count
+}
+
+func (c *comfySet[V]) SymmetricDifference(other Set[V]) Set[V] {
+	diff := c.filter(func(v V) bool {
+		return !other.Contains(v)
+	}).(*comfySet[V])
+	for v := range other.Values() {
+		if !c.Contains(v) {
+			diff.add(v)
+		}
+	}
+	return diff
+}
+
+func (c *comfySet[V]) Union(other Set[V]) Set[V] {
+	union := c.copy().(*comfySet[V])
+	for v := range other.Values() {
+		union.add(v)
+	}
+	return union
+}
+
+func (c *comfySet[V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range c.s {
+			if !yield(v) {
+				break
+			}
+		}
+	}
+}
+
+func (c *comfySet[V]) ValuesRev() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for i := len(c.s) - 1; i >= 0; i-- {
+			if !yield(c.s[i]) {
+				break
+			}
+		}
+	}
+}
+
+// Private:
+
+func (c *comfySet[V]) add(v V) {
+	if _, exists := c.kp[v]; exists {
+		return
+	}
+	c.kp[v] = len(c.s)
+	c.s = append(c.s, v)
+}
+
+//nolint:unused
+func (c *comfySet[V]) copy() baseInternal[V] {
+	newCs := &comfySet[V]{
+		s:  []V(nil),
+		kp: make(map[V]int, len(c.kp)),
+	}
+	newCs.s = append(newCs.s, c.s...)
+	for v, i := range c.kp {
+		newCs.kp[v] = i
+	}
+
+	return newCs
+}
+
+func (c *comfySet[V]) filter(predicate Predicate[V]) baseInternal[V] {
+	filtered := NewSet[V]().(*comfySet[V])
+	for _, v := range c.s {
+		if predicate(v) {
+			filtered.add(v)
+		}
+	}
+	return filtered
+}
+
+func (c *comfySet[V]) remap(f Mapper[V]) baseInternal[V] {
+	mapped := NewSet[V]().(*comfySet[V])
+	for _, v := range c.s {
+		mapped.add(f(v))
+	}
+	return mapped
+}
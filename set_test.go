@@ -0,0 +1,271 @@
+package coll
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+type comfySetIntBuilder[C baseInternal[int]] struct {
+}
+
+func (lcb *comfySetIntBuilder[C]) Empty() C {
+	return any(lcb.make([]int(nil))).(C)
+}
+
+func (lcb *comfySetIntBuilder[C]) One() C {
+	return any(lcb.make([]int{111})).(C)
+}
+
+func (lcb *comfySetIntBuilder[C]) Two() C {
+	return any(lcb.make([]int{123, 234})).(C)
+}
+
+func (lcb *comfySetIntBuilder[C]) Three() C {
+	return any(lcb.make([]int{111, 222, 333})).(C)
+}
+
+func (lcb *comfySetIntBuilder[C]) ThreeRev() C {
+	return any(lcb.make([]int{333, 222, 111})).(C)
+}
+
+func (lcb *comfySetIntBuilder[C]) SixWithDuplicates() C {
+	return any(lcb.make([]int{111, 222, 333, 111, 222, 333})).(C)
+}
+
+func (lcb *comfySetIntBuilder[C]) FromValues(values []any) C {
+	c := lcb.make([]int{})
+	for _, v := range values {
+		c.Add(v.(int))
+	}
+	return any(c).(C)
+}
+
+func (lcb *comfySetIntBuilder[C]) make(items []int) *comfySet[int] {
+	coll := &comfySet[int]{
+		s:  []int(nil),
+		kp: make(map[int]int),
+	}
+	coll.Add(items...)
+
+	return coll
+}
+
+func (lcb *comfySetIntBuilder[C]) extractRawValues(c C) any {
+	return lcb.extractUnderlyingSlice(c)
+}
+
+func (lcb *comfySetIntBuilder[C]) extractUnderlyingSlice(c C) any {
+	return (any(c)).(*comfySet[int]).s
+}
+
+func (lcb *comfySetIntBuilder[C]) extractUnderlyingMap(_ C) any {
+	return nil
+}
+
+func (lcb *comfySetIntBuilder[C]) extractUnderlyingKp(c C) any {
+	return (any(c)).(*comfySet[int]).kp
+}
+
+func (lcb *comfySetIntBuilder[C]) extractUnderlyingValsCount(_ C) any {
+	return nil
+}
+
+func TestNewSet(t *testing.T) {
+	intSet := NewSet[int]()
+	if intSet == nil {
+		t.Error("NewSet[int]() returned nil")
+	}
+	if !reflect.DeepEqual(intSet, &comfySet[int]{s: []int(nil), kp: make(map[int]int)}) {
+		t.Error("NewSet[int]() did not return a comfySet[int]")
+	}
+}
+
+func TestNewSetFrom(t *testing.T) {
+	intSet := NewSetFrom([]int{3, 1, 3, 2, 1})
+	want := &comfySet[int]{
+		s:  []int{3, 1, 2},
+		kp: map[int]int{3: 0, 1: 1, 2: 2},
+	}
+	if !reflect.DeepEqual(intSet, want) {
+		t.Errorf("NewSetFrom[int]() = %v, want %v", intSet, want)
+	}
+}
+
+func Test_comfySet_Add(t *testing.T) {
+	cases := []struct {
+		name   string
+		coll   *comfySet[int]
+		values []int
+		want   []int
+	}{
+		{
+			name:   "Add() on empty set",
+			coll:   NewSet[int]().(*comfySet[int]),
+			values: []int{1, 2},
+			want:   []int{1, 2},
+		},
+		{
+			name:   "Add() existing value keeps position",
+			coll:   NewSetFrom([]int{1, 2, 3}).(*comfySet[int]),
+			values: []int{1, 4},
+			want:   []int{1, 2, 3, 4},
+		},
+		{
+			name:   "Add() duplicates in arguments",
+			coll:   NewSetFrom([]int{1}).(*comfySet[int]),
+			values: []int{2, 2, 2},
+			want:   []int{1, 2},
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.coll.Add(tt.values...)
+			if !reflect.DeepEqual(tt.coll.s, tt.want) {
+				t.Errorf("Add() resulted in: %v, but wanted %v", tt.coll.s, tt.want)
+			}
+			if !reflect.DeepEqual(tt.coll.kp, comfyMakeKeyPosMap(tt.want)) {
+				t.Errorf("Add() resulted in inconsistent positions: %v", tt.coll.kp)
+			}
+		})
+	}
+}
+
+func Test_comfySet_Apply(t *testing.T) {
+	t.Run("Apply() deduplicates mapped values", func(t *testing.T) {
+		coll := NewSetFrom([]int{1, 2, 3, 4})
+		coll.Apply(func(v int) int { return v / 2 })
+		if got := slices.Collect(coll.Values()); !reflect.DeepEqual(got, []int{0, 1, 2}) {
+			t.Errorf("Apply() resulted in: %v, but wanted %v", got, []int{0, 1, 2})
+		}
+	})
+}
+
+func Test_comfySet_Clear(t *testing.T) {
+	testClear(t, &comfySetIntBuilder[mutableInternal[int]]{})
+}
+
+func Test_comfySet_Contains(t *testing.T) {
+	coll := NewSetFrom([]int{111, 222})
+	if !coll.Contains(111) {
+		t.Error("Contains(111) = false, want true")
+	}
+	if coll.Contains(333) {
+		t.Error("Contains(333) = true, want false")
+	}
+}
+
+func Test_comfySet_IsEmpty(t *testing.T) {
+	testIsEmpty(t, &comfySetIntBuilder[baseInternal[int]]{})
+}
+
+func Test_comfySet_Len(t *testing.T) {
+	testLen(t, &comfySetIntBuilder[baseInternal[int]]{})
+}
+
+func Test_comfySet_Remove(t *testing.T) {
+	coll := NewSetFrom([]int{1, 2, 3, 4}).(*comfySet[int])
+	if count := coll.Remove(2, 4, 5, 2); count != 2 {
+		t.Errorf("Remove() returned wrong count: %v, but wanted = %v", count, 2)
+	}
+	if !reflect.DeepEqual(coll.s, []int{1, 3}) {
+		t.Errorf("Remove() resulted in: %v, but wanted %v", coll.s, []int{1, 3})
+	}
+	if !reflect.DeepEqual(coll.kp, map[int]int{1: 0, 3: 1}) {
+		t.Errorf("Remove() resulted in inconsistent positions: %v", coll.kp)
+	}
+	if count := coll.Remove(9); count != 0 {
+		t.Errorf("Remove() of missing value returned: %v, but wanted = %v", count, 0)
+	}
+}
+
+func Test_comfySet_RemoveMatching(t *testing.T) {
+	testRemoveMatching(t, &comfySetIntBuilder[mutableInternal[int]]{})
+}
+
+func Test_comfySet_setOperations(t *testing.T) {
+	a := NewSetFrom([]int{1, 2, 3, 4})
+	b := NewSetFrom([]int{6, 4, 5, 3})
+
+	cases := []struct {
+		name string
+		got  Set[int]
+		want []int
+	}{
+		{name: "Union()", got: a.Union(b), want: []int{1, 2, 3, 4, 6, 5}},
+		{name: "Intersection()", got: a.Intersection(b), want: []int{3, 4}},
+		{name: "Difference()", got: a.Difference(b), want: []int{1, 2}},
+		{name: "SymmetricDifference()", got: a.SymmetricDifference(b), want: []int{1, 2, 6, 5}},
+		{name: "Union() with empty", got: a.Union(NewSet[int]()), want: []int{1, 2, 3, 4}},
+		{name: "Intersection() with empty", got: a.Intersection(NewSet[int]()), want: []int(nil)},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := slices.Collect(tt.got.Values()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("operands are not modified", func(t *testing.T) {
+		if got := slices.Collect(a.Values()); !reflect.DeepEqual(got, []int{1, 2, 3, 4}) {
+			t.Errorf("left operand was modified: %v", got)
+		}
+		if got := slices.Collect(b.Values()); !reflect.DeepEqual(got, []int{6, 4, 5, 3}) {
+			t.Errorf("right operand was modified: %v", got)
+		}
+	})
+}
+
+func Test_comfySet_IsSubsetOf(t *testing.T) {
+	cases := []struct {
+		name  string
+		a     Set[int]
+		b     Set[int]
+		want1 bool
+		want2 bool
+	}{
+		{name: "empty and empty", a: NewSet[int](), b: NewSet[int](), want1: true, want2: true},
+		{name: "empty and non-empty", a: NewSet[int](), b: NewSetFrom([]int{1}), want1: true, want2: false},
+		{name: "proper subset", a: NewSetFrom([]int{2, 1}), b: NewSetFrom([]int{1, 2, 3}), want1: true, want2: false},
+		{name: "equal sets", a: NewSetFrom([]int{1, 2}), b: NewSetFrom([]int{2, 1}), want1: true, want2: true},
+		{name: "disjoint sets", a: NewSetFrom([]int{1, 2}), b: NewSetFrom([]int{3, 4}), want1: false, want2: false},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.IsSubsetOf(tt.b); got != tt.want1 {
+				t.Errorf("IsSubsetOf() = %v, want %v", got, tt.want1)
+			}
+			if got := tt.b.IsSupersetOf(tt.a); got != tt.want1 {
+				t.Errorf("IsSupersetOf() = %v, want %v", got, tt.want1)
+			}
+			if got := tt.a.IsSupersetOf(tt.b); got != tt.want2 {
+				t.Errorf("reversed IsSupersetOf() = %v, want %v", got, tt.want2)
+			}
+		})
+	}
+}
+
+func Test_comfySet_Values(t *testing.T) {
+	testValues(t, &comfySetIntBuilder[baseInternal[int]]{})
+	testValuesBreak(t, &comfySetIntBuilder[baseInternal[int]]{})
+}
+
+func Test_comfySet_ValuesRev(t *testing.T) {
+	testValuesRev(t, &comfySetIntBuilder[orderedInternal[int]]{})
+	testValuesRevBreak(t, &comfySetIntBuilder[orderedInternal[int]]{})
+}
+
+func Test_comfySet_copy(t *testing.T) {
+	testCopy(t, &comfySetIntBuilder[baseInternal[int]]{})
+}
+
+func Test_comfySet_copy_pointer(t *testing.T) {
+	c1 := NewSetFrom([]int{123, 234, 345}).(*comfySet[int])
+	c2 := Copy[Set[int]](c1)
+
+	c1.Add(999)
+	if c2.Contains(999) {
+		t.Error("copy() did not create a deep copy")
+	}
+}
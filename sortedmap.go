@@ -0,0 +1,269 @@
+package coll
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// SortedMap is a Map that keeps its pairs sorted by key in ascending order.
+//
+// Unlike Map, the position of a pair is determined by its key, not by the order of insertion.
+// Consequently, Append and Prepend behave like SetMany, while Reverse and Sort do nothing.
+// Lookups, insertions, removals and index-based access are O(log n).
+type SortedMap[K cmp.Ordered, V any] interface {
+	Map[K, V]
+
+	// Ceiling returns the pair with the least key greater than or equal to the given key.
+	Ceiling(key K) (Pair[K, V], bool)
+
+	// First returns the pair with the least key.
+	First() (Pair[K, V], bool)
+
+	// Floor returns the pair with the greatest key less than or equal to the given key.
+	Floor(key K) (Pair[K, V], bool)
+
+	// Higher returns the pair with the least key strictly greater than the given key.
+	Higher(key K) (Pair[K, V], bool)
+
+	// Last returns the pair with the greatest key.
+	Last() (Pair[K, V], bool)
+
+	// Lower returns the pair with the greatest key strictly less than the given key.
+	Lower(key K) (Pair[K, V], bool)
+
+	// Range returns an iterator over pairs with keys in the half-open range [from, to).
+	Range(from, to K) iter.Seq[Pair[K, V]]
+}
+
+type comfySortedMap[K cmp.Ordered, V any] struct {
+	t sortedTree[K, Pair[K, V]]
+}
+
+// NewSortedMap creates a new SortedMap instance.
+func NewSortedMap[K cmp.Ordered, V any]() SortedMap[K, V] {
+	return &comfySortedMap[K, V]{}
+}
+
+// NewSortedMapFrom creates a new SortedMap instance from a slice of pairs.
+// If the slice contains duplicated keys, the last pair wins.
+func NewSortedMapFrom[K cmp.Ordered, V any](s []Pair[K, V]) SortedMap[K, V] {
+	sm := NewSortedMap[K, V]()
+	sm.SetMany(s)
+	return sm
+}
+
+func (c *comfySortedMap[K, V]) Append(p ...Pair[K, V]) {
+	c.SetMany(p)
+}
+
+func (c *comfySortedMap[K, V]) AppendColl(coll Ordered[Pair[K, V]]) {
+	c.SetMany(slices.Collect(coll.Values()))
+}
+
+func (c *comfySortedMap[K, V]) Apply(f Mapper[Pair[K, V]]) {
+	mapped := make([]Pair[K, V], 0, c.t.len())
+	c.t.ascend(func(n *sortedTreeNode[K, Pair[K, V]]) bool {
+		mapped = append(mapped, f(n.val))
+		return true
+	})
+	c.t.clear()
+	c.SetMany(mapped)
+}
+
+func (c *comfySortedMap[K, V]) At(i int) (p Pair[K, V], found bool) {
+	return c.pairOf(c.t.at(i))
+}
+
+func (c *comfySortedMap[K, V]) AtOrDefault(i int, defaultValue Pair[K, V]) Pair[K, V] {
+	if p, ok := c.At(i); ok {
+		return p
+	}
+	return defaultValue
+}
+
+func (c *comfySortedMap[K, V]) Ceiling(k K) (Pair[K, V], bool) {
+	return c.pairOf(c.t.ceiling(k))
+}
+
+func (c *comfySortedMap[K, V]) Clear() {
+	c.t.clear()
+}
+
+func (c *comfySortedMap[K, V]) First() (Pair[K, V], bool) {
+	return c.pairOf(c.t.first())
+}
+
+func (c *comfySortedMap[K, V]) Floor(k K) (Pair[K, V], bool) {
+	return c.pairOf(c.t.floor(k))
+}
+
+func (c *comfySortedMap[K, V]) Get(k K) (V, bool) {
+	n := c.t.get(k)
+	if n == nil {
+		var v V
+		return v, false
+	}
+	return n.val.Val(), true
+}
+
+func (c *comfySortedMap[K, V]) GetOrDefault(k K, defaultValue V) V {
+	n := c.t.get(k)
+	if n == nil {
+		return defaultValue
+	}
+	return n.val.Val()
+}
+
+func (c *comfySortedMap[K, V]) Has(k K) bool {
+	return c.t.get(k) != nil
+}
+
+func (c *comfySortedMap[K, V]) Higher(k K) (Pair[K, V], bool) {
+	return c.pairOf(c.t.higher(k))
+}
+
+func (c *comfySortedMap[K, V]) IsEmpty() bool {
+	return c.t.len() == 0
+}
+
+func (c *comfySortedMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		c.t.ascend(func(n *sortedTreeNode[K, Pair[K, V]]) bool {
+			return yield(n.key)
+		})
+	}
+}
+
+func (c *comfySortedMap[K, V]) KeyValues() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		c.t.ascend(func(n *sortedTreeNode[K, Pair[K, V]]) bool {
+			return yield(n.key, n.val.Val())
+		})
+	}
+}
+
+func (c *comfySortedMap[K, V]) Last() (Pair[K, V], bool) {
+	return c.pairOf(c.t.last())
+}
+
+func (c *comfySortedMap[K, V]) Len() int {
+	return c.t.len()
+}
+
+func (c *comfySortedMap[K, V]) Lower(k K) (Pair[K, V], bool) {
+	return c.pairOf(c.t.lower(k))
+}
+
+func (c *comfySortedMap[K, V]) Prepend(p ...Pair[K, V]) {
+	c.SetMany(p)
+}
+
+func (c *comfySortedMap[K, V]) Range(from, to K) iter.Seq[Pair[K, V]] {
+	return func(yield func(Pair[K, V]) bool) {
+		c.t.ascendFrom(from, func(n *sortedTreeNode[K, Pair[K, V]]) bool {
+			return cmp.Less(n.key, to) && yield(n.val)
+		})
+	}
+}
+
+func (c *comfySortedMap[K, V]) Remove(k K) {
+	c.t.delete(k)
+}
+
+func (c *comfySortedMap[K, V]) RemoveAt(idx int) (removed Pair[K, V], err error) {
+	n := c.t.at(idx)
+	if n == nil {
+		return nil, ErrOutOfBounds
+	}
+	c.t.delete(n.key)
+	return n.val, nil
+}
+
+func (c *comfySortedMap[K, V]) RemoveMany(keys []K) {
+	for _, k := range keys {
+		c.t.delete(k)
+	}
+}
+
+func (c *comfySortedMap[K, V]) RemoveMatching(predicate Predicate[Pair[K, V]]) (count int) {
+	kept := make([]*sortedTreeNode[K, Pair[K, V]], 0, c.t.len())
+	c.t.ascend(func(n *sortedTreeNode[K, Pair[K, V]]) bool {
+		if predicate(n.val) {
+			count++
+		} else {
+			kept = append(kept, n)
+		}
+		return true
+	})
+	if count > 0 {
+		c.t.rebuild(kept)
+	}
+	return count
+}
+
+// Reverse does nothing, because the order of a SortedMap is always determined by its keys.
+func (c *comfySortedMap[K, V]) Reverse() {
+}
+
+func (c *comfySortedMap[K, V]) Set(k K, v V) {
+	c.t.put(k, NewPair(k, v))
+}
+
+func (c *comfySortedMap[K, V]) SetMany(s []Pair[K, V]) {
+	for _, pair := range s {
+		c.t.put(pair.Key(), pair)
+	}
+}
+
+// Sort does nothing, because the order of a SortedMap is always determined by its keys.
+func (c *comfySortedMap[K, V]) Sort(_ PairComparator[K, V]) {
+}
+
+func (c *comfySortedMap[K, V]) Values() iter.Seq[Pair[K, V]] {
+	return func(yield func(Pair[K, V]) bool) {
+		c.t.ascend(func(n *sortedTreeNode[K, Pair[K, V]]) bool {
+			return yield(n.val)
+		})
+	}
+}
+
+func (c *comfySortedMap[K, V]) ValuesRev() iter.Seq[Pair[K, V]] {
+	return func(yield func(Pair[K, V]) bool) {
+		c.t.descend(func(n *sortedTreeNode[K, Pair[K, V]]) bool {
+			return yield(n.val)
+		})
+	}
+}
+
+// Private:
+
+//nolint:unused
+func (c *comfySortedMap[K, V]) copy() baseInternal[Pair[K, V]] {
+	return &comfySortedMap[K, V]{
+		t: c.t.clone(func(pair Pair[K, V]) Pair[K, V] {
+			return pair.copy()
+		}),
+	}
+}
+
+// filter returns a new map with copies of the pairs that match the predicate.
+func (c *comfySortedMap[K, V]) filter(predicate Predicate[Pair[K, V]]) baseInternal[Pair[K, V]] {
+	kept := make([]*sortedTreeNode[K, Pair[K, V]], 0, c.t.len())
+	c.t.ascend(func(n *sortedTreeNode[K, Pair[K, V]]) bool {
+		if predicate(n.val) {
+			kept = append(kept, &sortedTreeNode[K, Pair[K, V]]{key: n.key, val: n.val.copy()})
+		}
+		return true
+	})
+	filtered := &comfySortedMap[K, V]{}
+	filtered.t.rebuild(kept)
+	return filtered
+}
+
+func (c *comfySortedMap[K, V]) pairOf(n *sortedTreeNode[K, Pair[K, V]]) (Pair[K, V], bool) {
+	if n == nil {
+		return nil, false
+	}
+	return n.val, true
+}
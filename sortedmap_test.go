@@ -0,0 +1,278 @@
+package coll
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func sortedMapKVs(m SortedMap[int, int]) [][2]int {
+	kvs := [][2]int(nil)
+	for k, v := range m.KeyValues() {
+		kvs = append(kvs, [2]int{k, v})
+	}
+	return kvs
+}
+
+func pairsToKVs(pairs []Pair[int, int]) [][2]int {
+	kvs := [][2]int(nil)
+	for _, p := range pairs {
+		kvs = append(kvs, [2]int{p.Key(), p.Val()})
+	}
+	return kvs
+}
+
+func TestNewSortedMap(t *testing.T) {
+	m := NewSortedMap[int, string]()
+	if m == nil {
+		t.Error("NewSortedMap[int, string]() returned nil")
+	}
+	if !reflect.DeepEqual(m, &comfySortedMap[int, string]{}) {
+		t.Error("NewSortedMap[int, string]() did not return a comfySortedMap[int, string]")
+	}
+}
+
+func TestNewSortedMapFrom(t *testing.T) {
+	m := NewSortedMapFrom([]Pair[int, int]{
+		NewPair(3, 333),
+		NewPair(1, 111),
+		NewPair(2, 222),
+		NewPair(1, 999),
+	})
+	want := [][2]int{{1, 999}, {2, 222}, {3, 333}}
+	if got := sortedMapKVs(m); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewSortedMapFrom() = %v, want %v", got, want)
+	}
+}
+
+func Test_comfySortedMap_Set(t *testing.T) {
+	m := NewSortedMap[int, int]()
+	m.Set(20, 2)
+	m.Set(10, 1)
+	m.Set(30, 3)
+	m.Set(20, 22)
+	m.Append(NewPair(5, 0))
+	m.Prepend(NewPair(40, 4))
+
+	want := [][2]int{{5, 0}, {10, 1}, {20, 22}, {30, 3}, {40, 4}}
+	if got := sortedMapKVs(m); !reflect.DeepEqual(got, want) {
+		t.Errorf("Set() resulted in: %v, but wanted %v", got, want)
+	}
+	if got := slices.Collect(m.Keys()); !reflect.DeepEqual(got, []int{5, 10, 20, 30, 40}) {
+		t.Errorf("Keys() = %v", got)
+	}
+	if m.Len() != 5 || m.IsEmpty() {
+		t.Errorf("Len() = %v, IsEmpty() = %v", m.Len(), m.IsEmpty())
+	}
+}
+
+func Test_comfySortedMap_Get(t *testing.T) {
+	m := NewSortedMapFrom([]Pair[int, int]{NewPair(1, 111), NewPair(2, 222)})
+
+	if v, ok := m.Get(2); !ok || v != 222 {
+		t.Errorf("Get(2) = %v, %v, want 222, true", v, ok)
+	}
+	if v, ok := m.Get(3); ok || v != 0 {
+		t.Errorf("Get(3) = %v, %v, want 0, false", v, ok)
+	}
+	if v := m.GetOrDefault(3, -1); v != -1 {
+		t.Errorf("GetOrDefault(3) = %v, want -1", v)
+	}
+	if v := m.GetOrDefault(1, -1); v != 111 {
+		t.Errorf("GetOrDefault(1) = %v, want 111", v)
+	}
+	if !m.Has(1) || m.Has(3) {
+		t.Error("Has() returned wrong result")
+	}
+}
+
+func Test_comfySortedMap_At(t *testing.T) {
+	m := NewSortedMapFrom([]Pair[int, int]{NewPair(3, 333), NewPair(1, 111), NewPair(2, 222)})
+
+	if p, ok := m.At(1); !ok || p.Key() != 2 {
+		t.Errorf("At(1) = %v, %v, want key 2", p, ok)
+	}
+	if p, ok := m.At(3); ok || p != nil {
+		t.Errorf("At(3) = %v, %v, want nil, false", p, ok)
+	}
+	def := NewPair(-1, -1)
+	if p := m.AtOrDefault(-1, def); p != def {
+		t.Errorf("AtOrDefault(-1) = %v, want default", p)
+	}
+	if p := m.AtOrDefault(0, def); p.Key() != 1 {
+		t.Errorf("AtOrDefault(0) = %v, want key 1", p)
+	}
+}
+
+func Test_comfySortedMap_navigation(t *testing.T) {
+	m := NewSortedMapFrom([]Pair[int, int]{NewPair(10, 1), NewPair(20, 2), NewPair(30, 3)})
+	empty := NewSortedMap[int, int]()
+
+	keyOf := func(p Pair[int, int], ok bool) any {
+		if !ok {
+			return nil
+		}
+		return p.Key()
+	}
+
+	cases := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{name: "Ceiling(15)", got: keyOf(m.Ceiling(15)), want: 20},
+		{name: "Ceiling(31)", got: keyOf(m.Ceiling(31)), want: nil},
+		{name: "Floor(15)", got: keyOf(m.Floor(15)), want: 10},
+		{name: "Floor(9)", got: keyOf(m.Floor(9)), want: nil},
+		{name: "Higher(20)", got: keyOf(m.Higher(20)), want: 30},
+		{name: "Lower(20)", got: keyOf(m.Lower(20)), want: 10},
+		{name: "First()", got: keyOf(m.First()), want: 10},
+		{name: "Last()", got: keyOf(m.Last()), want: 30},
+		{name: "First() on empty", got: keyOf(empty.First()), want: nil},
+		{name: "Last() on empty", got: keyOf(empty.Last()), want: nil},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_comfySortedMap_Range(t *testing.T) {
+	m := NewSortedMap[int, int]()
+	for i := 1; i <= 10; i++ {
+		m.Set(i*10, i)
+	}
+
+	cases := []struct {
+		name     string
+		from, to int
+		want     []int
+	}{
+		{name: "Range() inside", from: 25, to: 60, want: []int{30, 40, 50}},
+		{name: "Range() on exact bounds", from: 30, to: 50, want: []int{30, 40}},
+		{name: "Range() below all", from: -10, to: 5, want: []int(nil)},
+		{name: "Range() over all", from: 0, to: 1000, want: []int{10, 20, 30, 40, 50, 60, 70, 80, 90, 100}},
+		{name: "Range() empty", from: 50, to: 50, want: []int(nil)},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []int(nil)
+			for p := range m.Range(tt.from, tt.to) {
+				got = append(got, p.Key())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Range() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("Range() break", func(t *testing.T) {
+		got := []int(nil)
+		for p := range m.Range(0, 1000) {
+			if p.Key() > 30 {
+				break
+			}
+			got = append(got, p.Key())
+		}
+		if !reflect.DeepEqual(got, []int{10, 20, 30}) {
+			t.Errorf("Range() with break = %v", got)
+		}
+	})
+}
+
+func Test_comfySortedMap_Remove(t *testing.T) {
+	m := NewSortedMapFrom([]Pair[int, int]{
+		NewPair(1, 111), NewPair(2, 222), NewPair(3, 333), NewPair(4, 444), NewPair(5, 555),
+	})
+
+	m.Remove(3)
+	m.Remove(99)
+	m.RemoveMany([]int{1, 5})
+	if got, want := sortedMapKVs(m), [][2]int{{2, 222}, {4, 444}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Remove() resulted in: %v, but wanted %v", got, want)
+	}
+
+	removed, err := m.RemoveAt(1)
+	if err != nil || removed.Key() != 4 {
+		t.Errorf("RemoveAt(1) = %v, %v, want key 4", removed, err)
+	}
+	if _, err = m.RemoveAt(1); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("RemoveAt(1) on one-item map returned %v, want ErrOutOfBounds", err)
+	}
+}
+
+func Test_comfySortedMap_RemoveMatching(t *testing.T) {
+	m := NewSortedMap[int, int]()
+	for i := range 20 {
+		m.Set(i, i*i)
+	}
+	count := m.RemoveMatching(func(p Pair[int, int]) bool { return p.Key()%2 == 1 })
+	if count != 10 {
+		t.Errorf("RemoveMatching() returned %d, want 10", count)
+	}
+	if got := slices.Collect(m.Keys()); !reflect.DeepEqual(got, []int{0, 2, 4, 6, 8, 10, 12, 14, 16, 18}) {
+		t.Errorf("RemoveMatching() resulted in: %v", got)
+	}
+	sortedTreeCheckInvariants(t, m.(*comfySortedMap[int, int]).t.root)
+}
+
+func Test_comfySortedMap_Apply(t *testing.T) {
+	m := NewSortedMapFrom([]Pair[int, int]{NewPair(1, 10), NewPair(2, 20), NewPair(3, 30)})
+	m.Apply(func(p Pair[int, int]) Pair[int, int] {
+		return NewPair(-p.Key(), p.Val()+1)
+	})
+	want := [][2]int{{-3, 31}, {-2, 21}, {-1, 11}}
+	if got := sortedMapKVs(m); !reflect.DeepEqual(got, want) {
+		t.Errorf("Apply() resulted in: %v, but wanted %v", got, want)
+	}
+}
+
+func Test_comfySortedMap_ReverseAndSortKeepKeyOrder(t *testing.T) {
+	m := NewSortedMapFrom([]Pair[int, int]{NewPair(1, 30), NewPair(2, 20), NewPair(3, 10)})
+	m.Reverse()
+	m.Sort(func(a, b Pair[int, int]) int { return a.Val() - b.Val() })
+	if got := slices.Collect(m.Keys()); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Errorf("Reverse() and Sort() changed the order: %v", got)
+	}
+}
+
+func Test_comfySortedMap_ValuesRev(t *testing.T) {
+	m := NewSortedMapFrom([]Pair[int, int]{NewPair(2, 222), NewPair(1, 111), NewPair(3, 333)})
+	want := [][2]int{{3, 333}, {2, 222}, {1, 111}}
+	if got := pairsToKVs(slices.Collect(m.ValuesRev())); !reflect.DeepEqual(got, want) {
+		t.Errorf("ValuesRev() = %v, want %v", got, want)
+	}
+}
+
+func Test_comfySortedMap_Values_preserveRef(t *testing.T) {
+	m := NewSortedMapFrom([]Pair[int, int]{NewPair(1, 111)})
+	for p := range m.Values() {
+		p.SetVal(999)
+	}
+	if v, _ := m.Get(1); v != 999 {
+		t.Errorf("Values() did not yield the stored pair, got %v", v)
+	}
+}
+
+func Test_comfySortedMap_copy(t *testing.T) {
+	c1 := NewSortedMapFrom([]Pair[int, int]{NewPair(1, 111), NewPair(2, 222), NewPair(3, 333)})
+	c2 := Copy(c1)
+
+	if !reflect.DeepEqual(c1, c2) {
+		t.Errorf("Copy() = %v, want %v", c2, c1)
+	}
+
+	p, _ := c1.At(0)
+	p.SetVal(999)
+	c1.Set(4, 444)
+	if v, _ := c2.Get(1); v != 111 {
+		t.Error("copy() did not copy pairs")
+	}
+	if c2.Has(4) {
+		t.Error("copy() did not create a deep copy")
+	}
+}
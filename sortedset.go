@@ -0,0 +1,199 @@
+package coll
+
+import (
+	"cmp"
+	"iter"
+)
+
+// SortedSet is a collection of unique elements that are kept sorted in ascending order.
+// Lookups, insertions, removals and index-based access are O(log n).
+type SortedSet[V cmp.Ordered] interface {
+	Indexed[V]
+	Mutable[V]
+	Cmp[V]
+
+	// Add adds the given values to the set.
+	Add(v ...V)
+
+	// RangeBetween returns an iterator over values in the closed range [lo, hi].
+	RangeBetween(lo, hi V) iter.Seq[V]
+
+	// Rank returns the number of values in the set that are strictly less than the given value.
+	// If the value is present in the set, this is also its index.
+	Rank(v V) int
+
+	// Remove removes the given values from the set.
+	// Returns the number of removed items.
+	Remove(v ...V) (count int)
+
+	// Select returns the i-th smallest value in the set. It is equivalent to At.
+	Select(i int) (V, bool)
+}
+
+type comfySortedSet[V cmp.Ordered] struct {
+	t sortedTree[V, struct{}]
+}
+
+// NewSortedSet creates a new SortedSet instance.
+func NewSortedSet[V cmp.Ordered]() SortedSet[V] {
+	return &comfySortedSet[V]{}
+}
+
+// NewSortedSetFrom creates a new SortedSet instance from a slice.
+func NewSortedSetFrom[V cmp.Ordered](s []V) SortedSet[V] {
+	ss := NewSortedSet[V]()
+	ss.Add(s...)
+	return ss
+}
+
+func (c *comfySortedSet[V]) Add(v ...V) {
+	for _, v := range v {
+		c.t.put(v, struct{}{})
+	}
+}
+
+func (c *comfySortedSet[V]) Apply(f Mapper[V]) {
+	mapped := make([]V, 0, c.t.len())
+	c.t.ascend(func(n *sortedTreeNode[V, struct{}]) bool {
+		mapped = append(mapped, f(n.key))
+		return true
+	})
+	c.t.clear()
+	c.Add(mapped...)
+}
+
+func (c *comfySortedSet[V]) At(i int) (V, bool) {
+	n := c.t.at(i)
+	if n == nil {
+		var v V
+		return v, false
+	}
+	return n.key, true
+}
+
+func (c *comfySortedSet[V]) AtOrDefault(i int, defaultValue V) V {
+	n := c.t.at(i)
+	if n == nil {
+		return defaultValue
+	}
+	return n.key
+}
+
+func (c *comfySortedSet[V]) Clear() {
+	c.t.clear()
+}
+
+func (c *comfySortedSet[V]) ContainsValue(v V) bool {
+	return c.t.get(v) != nil
+}
+
+func (c *comfySortedSet[V]) CountValues(v V) int {
+	if c.ContainsValue(v) {
+		return 1
+	}
+	return 0
+}
+
+func (c *comfySortedSet[V]) HasValue(v V) bool {
+	return c.ContainsValue(v)
+}
+
+func (c *comfySortedSet[V]) IndexOf(v V) (i int, found bool) {
+	if !c.ContainsValue(v) {
+		return -1, false
+	}
+	return c.t.rank(v), true
+}
+
+func (c *comfySortedSet[V]) IsEmpty() bool {
+	return c.t.len() == 0
+}
+
+func (c *comfySortedSet[V]) LastIndexOf(v V) (i int, found bool) {
+	return c.IndexOf(v)
+}
+
+func (c *comfySortedSet[V]) Len() int {
+	return c.t.len()
+}
+
+func (c *comfySortedSet[V]) RangeBetween(lo, hi V) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		c.t.ascendFrom(lo, func(n *sortedTreeNode[V, struct{}]) bool {
+			return cmp.Compare(n.key, hi) <= 0 && yield(n.key)
+		})
+	}
+}
+
+func (c *comfySortedSet[V]) Rank(v V) int {
+	return c.t.rank(v)
+}
+
+func (c *comfySortedSet[V]) Remove(v ...V) (count int) {
+	for _, v := range v {
+		if c.t.delete(v) != nil {
+			count++
+		}
+	}
+	return count
+}
+
+func (c *comfySortedSet[V]) RemoveMatching(predicate Predicate[V]) (count int) {
+	kept := make([]*sortedTreeNode[V, struct{}], 0, c.t.len())
+	c.t.ascend(func(n *sortedTreeNode[V, struct{}]) bool {
+		if predicate(n.key) {
+			count++
+		} else {
+			kept = append(kept, n)
+		}
+		return true
+	})
+	if count > 0 {
+		c.t.rebuild(kept)
+	}
+	return count
+}
+
+func (c *comfySortedSet[V]) Select(i int) (V, bool) {
+	return c.At(i)
+}
+
+func (c *comfySortedSet[V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		c.t.ascend(func(n *sortedTreeNode[V, struct{}]) bool {
+			return yield(n.key)
+		})
+	}
+}
+
+func (c *comfySortedSet[V]) ValuesRev() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		c.t.descend(func(n *sortedTreeNode[V, struct{}]) bool {
+			return yield(n.key)
+		})
+	}
+}
+
+// Private:
+
+//nolint:unused
+func (c *comfySortedSet[V]) copy() baseInternal[V] {
+	return &comfySortedSet[V]{
+		t: c.t.clone(func(val struct{}) struct{} {
+			return val
+		}),
+	}
+}
+
+func (c *comfySortedSet[V]) filter(predicate Predicate[V]) baseInternal[V] {
+	kept := make([]*sortedTreeNode[V, struct{}], 0, c.t.len())
+	c.t.ascend(func(n *sortedTreeNode[V, struct{}]) bool {
+		if predicate(n.key) {
+			kept = append(kept, &sortedTreeNode[V, struct{}]{key: n.key})
+		}
+		return true
+	})
+	filtered := &comfySortedSet[V]{}
+	filtered.t.rebuild(kept)
+	return filtered
+}
@@ -0,0 +1,252 @@
+package coll
+
+import (
+	"math"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+type comfySortedSetIntBuilder[C any] struct {
+}
+
+func (lcb *comfySortedSetIntBuilder[C]) Empty() C {
+	return any(lcb.make([]int(nil))).(C)
+}
+
+func (lcb *comfySortedSetIntBuilder[C]) One() C {
+	return any(lcb.make([]int{111})).(C)
+}
+
+func (lcb *comfySortedSetIntBuilder[C]) Two() C {
+	return any(lcb.make([]int{123, 234})).(C)
+}
+
+func (lcb *comfySortedSetIntBuilder[C]) Three() C {
+	return any(lcb.make([]int{111, 222, 333})).(C)
+}
+
+func (lcb *comfySortedSetIntBuilder[C]) ThreeRev() C {
+	return any(lcb.make([]int{333, 222, 111})).(C)
+}
+
+func (lcb *comfySortedSetIntBuilder[C]) SixWithDuplicates() C {
+	return any(lcb.make([]int{111, 222, 333, 111, 222, 333})).(C)
+}
+
+func (lcb *comfySortedSetIntBuilder[C]) FromValues(values []any) C {
+	c := lcb.make([]int{})
+	for _, v := range values {
+		c.Add(v.(int))
+	}
+	return any(c).(C)
+}
+
+func (lcb *comfySortedSetIntBuilder[C]) make(items []int) *comfySortedSet[int] {
+	return NewSortedSetFrom(items).(*comfySortedSet[int])
+}
+
+func (lcb *comfySortedSetIntBuilder[C]) extractRawValues(c C) any {
+	return lcb.extractUnderlyingSlice(c)
+}
+
+func (lcb *comfySortedSetIntBuilder[C]) extractUnderlyingSlice(c C) any {
+	return slices.Collect((any(c)).(*comfySortedSet[int]).Values())
+}
+
+func (lcb *comfySortedSetIntBuilder[C]) extractUnderlyingMap(_ C) any {
+	return nil
+}
+
+func (lcb *comfySortedSetIntBuilder[C]) extractUnderlyingKp(_ C) any {
+	return nil
+}
+
+func (lcb *comfySortedSetIntBuilder[C]) extractUnderlyingValsCount(_ C) any {
+	return nil
+}
+
+func TestNewSortedSet(t *testing.T) {
+	s := NewSortedSet[string]()
+	if s == nil {
+		t.Error("NewSortedSet[string]() returned nil")
+	}
+	if !reflect.DeepEqual(s, &comfySortedSet[string]{}) {
+		t.Error("NewSortedSet[string]() did not return a comfySortedSet[string]")
+	}
+}
+
+func TestNewSortedSetFrom(t *testing.T) {
+	s := NewSortedSetFrom([]int{5, 1, 3, 1, 5})
+	if got := slices.Collect(s.Values()); !reflect.DeepEqual(got, []int{1, 3, 5}) {
+		t.Errorf("NewSortedSetFrom() = %v, want %v", got, []int{1, 3, 5})
+	}
+}
+
+func Test_comfySortedSet_Add(t *testing.T) {
+	s := NewSortedSet[int]()
+	s.Add(30, 10)
+	s.Add(20, 10)
+	if got := slices.Collect(s.Values()); !reflect.DeepEqual(got, []int{10, 20, 30}) {
+		t.Errorf("Add() resulted in: %v, but wanted %v", got, []int{10, 20, 30})
+	}
+}
+
+func Test_comfySortedSet_Apply(t *testing.T) {
+	s := NewSortedSetFrom([]int{1, 2, 3, 4})
+	s.Apply(func(v int) int { return -v / 2 })
+	if got := slices.Collect(s.Values()); !reflect.DeepEqual(got, []int{-2, -1, 0}) {
+		t.Errorf("Apply() resulted in: %v, but wanted %v", got, []int{-2, -1, 0})
+	}
+}
+
+func Test_comfySortedSet_At(t *testing.T) {
+	testAt(t, &comfySortedSetIntBuilder[indexedInternal[int]]{})
+}
+
+func Test_comfySortedSet_AtOrDefault(t *testing.T) {
+	testAtOrDefault(t, &comfySortedSetIntBuilder[indexedInternal[int]]{})
+}
+
+func Test_comfySortedSet_Clear(t *testing.T) {
+	testClear(t, &comfySortedSetIntBuilder[mutableInternal[int]]{})
+}
+
+func Test_comfySortedSet_ContainsValue(t *testing.T) {
+	testContainsValue(t, &comfySortedSetIntBuilder[cmpBaseInternal[int, int]]{})
+}
+
+func Test_comfySortedSet_CountValues(t *testing.T) {
+	s := NewSortedSetFrom([]int{111, 222, 111})
+	if got := s.CountValues(111); got != 1 {
+		t.Errorf("CountValues(111) = %v, want 1", got)
+	}
+	if got := s.CountValues(333); got != 0 {
+		t.Errorf("CountValues(333) = %v, want 0", got)
+	}
+}
+
+func Test_comfySortedSet_HasValue(t *testing.T) {
+	testHasValue(t, &comfySortedSetIntBuilder[cmpBaseInternal[int, int]]{})
+}
+
+func Test_comfySortedSet_IndexOf(t *testing.T) {
+	s := NewSortedSetFrom([]int{40, 10, 30, 20})
+	cases := []struct {
+		value     int
+		wantIdx   int
+		wantFound bool
+	}{
+		{value: 10, wantIdx: 0, wantFound: true},
+		{value: 30, wantIdx: 2, wantFound: true},
+		{value: 40, wantIdx: 3, wantFound: true},
+		{value: 25, wantIdx: -1, wantFound: false},
+	}
+	for _, tt := range cases {
+		if i, found := s.IndexOf(tt.value); i != tt.wantIdx || found != tt.wantFound {
+			t.Errorf("IndexOf(%d) = %v, %v, want %v, %v", tt.value, i, found, tt.wantIdx, tt.wantFound)
+		}
+		if i, found := s.LastIndexOf(tt.value); i != tt.wantIdx || found != tt.wantFound {
+			t.Errorf("LastIndexOf(%d) = %v, %v, want %v, %v", tt.value, i, found, tt.wantIdx, tt.wantFound)
+		}
+	}
+}
+
+func Test_comfySortedSet_IsEmpty(t *testing.T) {
+	testIsEmpty(t, &comfySortedSetIntBuilder[baseInternal[int]]{})
+}
+
+func Test_comfySortedSet_Len(t *testing.T) {
+	testLen(t, &comfySortedSetIntBuilder[baseInternal[int]]{})
+}
+
+func Test_comfySortedSet_RangeBetween(t *testing.T) {
+	s := NewSortedSetFrom([]int{10, 20, 30, 40, 50})
+	cases := []struct {
+		name   string
+		lo, hi int
+		want   []int
+	}{
+		{name: "RangeBetween() inclusive bounds", lo: 20, hi: 40, want: []int{20, 30, 40}},
+		{name: "RangeBetween() between values", lo: 15, hi: 45, want: []int{20, 30, 40}},
+		{name: "RangeBetween() single value", lo: 30, hi: 30, want: []int{30}},
+		{name: "RangeBetween() outside", lo: 51, hi: 100, want: []int(nil)},
+		{name: "RangeBetween() inverted", lo: 40, hi: 20, want: []int(nil)},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := slices.Collect(s.RangeBetween(tt.lo, tt.hi)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RangeBetween() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("RangeBetween() with NaN", func(t *testing.T) {
+		s := NewSortedSetFrom([]float64{2, math.NaN(), 1})
+		got := slices.Collect(s.RangeBetween(math.NaN(), 1))
+		if len(got) != 2 || !math.IsNaN(got[0]) || got[1] != 1 {
+			t.Errorf("RangeBetween(NaN, 1) = %v, want [NaN 1]", got)
+		}
+	})
+}
+
+func Test_comfySortedSet_RankAndSelect(t *testing.T) {
+	s := NewSortedSet[int]()
+	for i := 100; i > 0; i-- {
+		s.Add(i * 2)
+	}
+
+	for i := range 100 {
+		v, ok := s.Select(i)
+		if !ok || v != (i+1)*2 {
+			t.Fatalf("Select(%d) = %v, %v, want %v", i, v, ok, (i+1)*2)
+		}
+		if r := s.Rank(v); r != i {
+			t.Fatalf("Rank(%d) = %v, want %v", v, r, i)
+		}
+		if r := s.Rank(v + 1); r != i+1 {
+			t.Fatalf("Rank(%d) = %v, want %v", v+1, r, i+1)
+		}
+	}
+	if _, ok := s.Select(100); ok {
+		t.Error("Select(100) returned a value for out of bounds index")
+	}
+}
+
+func Test_comfySortedSet_Remove(t *testing.T) {
+	s := NewSortedSetFrom([]int{1, 2, 3, 4, 5})
+	if count := s.Remove(2, 4, 6, 2); count != 2 {
+		t.Errorf("Remove() returned %v, want 2", count)
+	}
+	if got := slices.Collect(s.Values()); !reflect.DeepEqual(got, []int{1, 3, 5}) {
+		t.Errorf("Remove() resulted in: %v, but wanted %v", got, []int{1, 3, 5})
+	}
+}
+
+func Test_comfySortedSet_RemoveMatching(t *testing.T) {
+	testRemoveMatching(t, &comfySortedSetIntBuilder[mutableInternal[int]]{})
+}
+
+func Test_comfySortedSet_Values(t *testing.T) {
+	testValues(t, &comfySortedSetIntBuilder[baseInternal[int]]{})
+	testValuesBreak(t, &comfySortedSetIntBuilder[baseInternal[int]]{})
+}
+
+func Test_comfySortedSet_ValuesRev(t *testing.T) {
+	testValuesRev(t, &comfySortedSetIntBuilder[orderedInternal[int]]{})
+	testValuesRevBreak(t, &comfySortedSetIntBuilder[orderedInternal[int]]{})
+}
+
+func Test_comfySortedSet_copy(t *testing.T) {
+	testCopy(t, &comfySortedSetIntBuilder[baseInternal[int]]{})
+}
+
+func Test_comfySortedSet_copy_pointer(t *testing.T) {
+	c1 := NewSortedSetFrom([]int{1, 2, 3})
+	c2 := Copy(c1)
+
+	c1.Add(999)
+	if c2.ContainsValue(999) {
+		t.Error("copy() did not create a deep copy")
+	}
+}
@@ -0,0 +1,346 @@
+package coll
+
+import (
+	"cmp"
+)
+
+// sortedTree is an AVL tree augmented with subtree sizes, which allows for O(log n) lookups by both key and index.
+// It is the shared backbone of the collections that keep their elements sorted.
+type sortedTree[K cmp.Ordered, E any] struct {
+	root *sortedTreeNode[K, E]
+}
+
+type sortedTreeNode[K cmp.Ordered, E any] struct {
+	key    K
+	val    E
+	left   *sortedTreeNode[K, E]
+	right  *sortedTreeNode[K, E]
+	height int
+	size   int
+}
+
+func (t *sortedTree[K, E]) len() int {
+	return t.root.getSize()
+}
+
+func (t *sortedTree[K, E]) clear() {
+	t.root = nil
+}
+
+// get returns the node with the given key or nil if there is no such node.
+func (t *sortedTree[K, E]) get(k K) *sortedTreeNode[K, E] {
+	n := t.root
+	for n != nil {
+		switch c := cmp.Compare(k, n.key); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n
+		}
+	}
+	return nil
+}
+
+// put inserts the given key or replaces the value of an existing one.
+// Returns true if a new node was inserted.
+func (t *sortedTree[K, E]) put(k K, val E) (inserted bool) {
+	t.root = t.root.insert(k, val, &inserted)
+	return inserted
+}
+
+// delete removes the node with the given key. Returns the removed node or nil if the key was not found.
+func (t *sortedTree[K, E]) delete(k K) (removed *sortedTreeNode[K, E]) {
+	t.root = t.root.delete(k, &removed)
+	return removed
+}
+
+// at returns the node at the given in-order position or nil if the index is out of bounds.
+func (t *sortedTree[K, E]) at(i int) *sortedTreeNode[K, E] {
+	if i < 0 || i >= t.len() {
+		return nil
+	}
+	n := t.root
+	for {
+		leftSize := n.left.getSize()
+		switch {
+		case i < leftSize:
+			n = n.left
+		case i > leftSize:
+			i -= leftSize + 1
+			n = n.right
+		default:
+			return n
+		}
+	}
+}
+
+// rank returns the number of keys that are strictly less than the given key.
+func (t *sortedTree[K, E]) rank(k K) int {
+	r := 0
+	n := t.root
+	for n != nil {
+		if cmp.Less(n.key, k) {
+			r += n.left.getSize() + 1
+			n = n.right
+		} else {
+			n = n.left
+		}
+	}
+	return r
+}
+
+func (t *sortedTree[K, E]) first() *sortedTreeNode[K, E] {
+	n := t.root
+	for n != nil && n.left != nil {
+		n = n.left
+	}
+	return n
+}
+
+func (t *sortedTree[K, E]) last() *sortedTreeNode[K, E] {
+	n := t.root
+	for n != nil && n.right != nil {
+		n = n.right
+	}
+	return n
+}
+
+// floor returns the node with the greatest key less than or equal to the given key.
+func (t *sortedTree[K, E]) floor(k K) *sortedTreeNode[K, E] {
+	return t.search(func(key K) bool { return cmp.Compare(key, k) <= 0 }, true)
+}
+
+// ceiling returns the node with the least key greater than or equal to the given key.
+func (t *sortedTree[K, E]) ceiling(k K) *sortedTreeNode[K, E] {
+	return t.search(func(key K) bool { return cmp.Compare(key, k) >= 0 }, false)
+}
+
+// lower returns the node with the greatest key strictly less than the given key.
+func (t *sortedTree[K, E]) lower(k K) *sortedTreeNode[K, E] {
+	return t.search(func(key K) bool { return cmp.Compare(key, k) < 0 }, true)
+}
+
+// higher returns the node with the least key strictly greater than the given key.
+func (t *sortedTree[K, E]) higher(k K) *sortedTreeNode[K, E] {
+	return t.search(func(key K) bool { return cmp.Compare(key, k) > 0 }, false)
+}
+
+// search finds the boundary node for a predicate that is monotonic over the keys.
+// When greatest is true, it returns the greatest key matching the predicate, otherwise the least one.
+func (t *sortedTree[K, E]) search(match func(key K) bool, greatest bool) *sortedTreeNode[K, E] {
+	var found *sortedTreeNode[K, E]
+	n := t.root
+	for n != nil {
+		if match(n.key) {
+			found = n
+			if greatest {
+				n = n.right
+			} else {
+				n = n.left
+			}
+		} else if greatest {
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	return found
+}
+
+// ascend visits all nodes in ascending key order until visit returns false.
+func (t *sortedTree[K, E]) ascend(visit func(n *sortedTreeNode[K, E]) bool) {
+	t.root.ascend(visit)
+}
+
+// ascendFrom visits nodes with keys greater than or equal to the given key, in ascending order,
+// until visit returns false.
+func (t *sortedTree[K, E]) ascendFrom(from K, visit func(n *sortedTreeNode[K, E]) bool) {
+	t.root.ascendFrom(from, visit)
+}
+
+// descend visits all nodes in descending key order until visit returns false.
+func (t *sortedTree[K, E]) descend(visit func(n *sortedTreeNode[K, E]) bool) {
+	t.root.descend(visit)
+}
+
+// clone creates a structural copy of the tree, copying values with the given function.
+func (t *sortedTree[K, E]) clone(copyVal func(val E) E) sortedTree[K, E] {
+	return sortedTree[K, E]{root: t.root.clone(copyVal)}
+}
+
+// rebuild replaces the tree contents with the given nodes, which must be sorted by key and unique.
+// It runs in O(n), which makes it cheaper than inserting nodes one by one after bulk removals.
+func (t *sortedTree[K, E]) rebuild(nodes []*sortedTreeNode[K, E]) {
+	t.root = sortedTreeBuild(nodes)
+}
+
+// nodes returns all nodes in ascending key order.
+func (t *sortedTree[K, E]) nodes() []*sortedTreeNode[K, E] {
+	nodes := make([]*sortedTreeNode[K, E], 0, t.len())
+	t.ascend(func(n *sortedTreeNode[K, E]) bool {
+		nodes = append(nodes, n)
+		return true
+	})
+	return nodes
+}
+
+// Node functions:
+
+func sortedTreeBuild[K cmp.Ordered, E any](nodes []*sortedTreeNode[K, E]) *sortedTreeNode[K, E] {
+	if len(nodes) == 0 {
+		return nil
+	}
+	mid := len(nodes) / 2
+	n := nodes[mid]
+	n.left = sortedTreeBuild(nodes[:mid])
+	n.right = sortedTreeBuild(nodes[mid+1:])
+	n.update()
+	return n
+}
+
+func (n *sortedTreeNode[K, E]) getHeight() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func (n *sortedTreeNode[K, E]) getSize() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *sortedTreeNode[K, E]) update() {
+	n.height = 1 + max(n.left.getHeight(), n.right.getHeight())
+	n.size = 1 + n.left.getSize() + n.right.getSize()
+}
+
+func (n *sortedTreeNode[K, E]) rotateLeft() *sortedTreeNode[K, E] {
+	r := n.right
+	n.right = r.left
+	r.left = n
+	n.update()
+	r.update()
+	return r
+}
+
+func (n *sortedTreeNode[K, E]) rotateRight() *sortedTreeNode[K, E] {
+	l := n.left
+	n.left = l.right
+	l.right = n
+	n.update()
+	l.update()
+	return l
+}
+
+func (n *sortedTreeNode[K, E]) rebalance() *sortedTreeNode[K, E] {
+	n.update()
+	balance := n.left.getHeight() - n.right.getHeight()
+	if balance > 1 {
+		if n.left.left.getHeight() < n.left.right.getHeight() {
+			n.left = n.left.rotateLeft()
+		}
+		return n.rotateRight()
+	}
+	if balance < -1 {
+		if n.right.right.getHeight() < n.right.left.getHeight() {
+			n.right = n.right.rotateRight()
+		}
+		return n.rotateLeft()
+	}
+	return n
+}
+
+func (n *sortedTreeNode[K, E]) insert(k K, val E, inserted *bool) *sortedTreeNode[K, E] {
+	if n == nil {
+		*inserted = true
+		return &sortedTreeNode[K, E]{key: k, val: val, height: 1, size: 1}
+	}
+	switch c := cmp.Compare(k, n.key); {
+	case c < 0:
+		n.left = n.left.insert(k, val, inserted)
+	case c > 0:
+		n.right = n.right.insert(k, val, inserted)
+	default:
+		n.val = val
+		return n
+	}
+	return n.rebalance()
+}
+
+func (n *sortedTreeNode[K, E]) delete(k K, removed **sortedTreeNode[K, E]) *sortedTreeNode[K, E] {
+	if n == nil {
+		return nil
+	}
+	switch c := cmp.Compare(k, n.key); {
+	case c < 0:
+		n.left = n.left.delete(k, removed)
+	case c > 0:
+		n.right = n.right.delete(k, removed)
+	default:
+		*removed = n
+		if n.left == nil {
+			return n.right
+		}
+		if n.right == nil {
+			return n.left
+		}
+		var successor *sortedTreeNode[K, E]
+		right := n.right.deleteMin(&successor)
+		successor.left = n.left
+		successor.right = right
+		return successor.rebalance()
+	}
+	return n.rebalance()
+}
+
+func (n *sortedTreeNode[K, E]) deleteMin(removed **sortedTreeNode[K, E]) *sortedTreeNode[K, E] {
+	if n.left == nil {
+		*removed = n
+		return n.right
+	}
+	n.left = n.left.deleteMin(removed)
+	return n.rebalance()
+}
+
+func (n *sortedTreeNode[K, E]) ascend(visit func(n *sortedTreeNode[K, E]) bool) bool {
+	if n == nil {
+		return true
+	}
+	return n.left.ascend(visit) && visit(n) && n.right.ascend(visit)
+}
+
+func (n *sortedTreeNode[K, E]) ascendFrom(from K, visit func(n *sortedTreeNode[K, E]) bool) bool {
+	if n == nil {
+		return true
+	}
+	if cmp.Less(n.key, from) {
+		return n.right.ascendFrom(from, visit)
+	}
+	return n.left.ascendFrom(from, visit) && visit(n) && n.right.ascend(visit)
+}
+
+func (n *sortedTreeNode[K, E]) descend(visit func(n *sortedTreeNode[K, E]) bool) bool {
+	if n == nil {
+		return true
+	}
+	return n.right.descend(visit) && visit(n) && n.left.descend(visit)
+}
+
+func (n *sortedTreeNode[K, E]) clone(copyVal func(val E) E) *sortedTreeNode[K, E] {
+	if n == nil {
+		return nil
+	}
+	return &sortedTreeNode[K, E]{
+		key:    n.key,
+		val:    copyVal(n.val),
+		left:   n.left.clone(copyVal),
+		right:  n.right.clone(copyVal),
+		height: n.height,
+		size:   n.size,
+	}
+}
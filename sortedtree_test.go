@@ -0,0 +1,179 @@
+package coll
+
+import (
+	"cmp"
+	"math"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func sortedTreeKeys[K cmp.Ordered, E any](t *sortedTree[K, E]) []K {
+	keys := []K(nil)
+	t.ascend(func(n *sortedTreeNode[K, E]) bool {
+		keys = append(keys, n.key)
+		return true
+	})
+	return keys
+}
+
+func sortedTreeCheckInvariants[K cmp.Ordered, E any](t *testing.T, n *sortedTreeNode[K, E]) {
+	t.Helper()
+	if n == nil {
+		return
+	}
+	if n.height != 1+max(n.left.getHeight(), n.right.getHeight()) {
+		t.Fatalf("node %v has invalid height %d", n.key, n.height)
+	}
+	if n.size != 1+n.left.getSize()+n.right.getSize() {
+		t.Fatalf("node %v has invalid size %d", n.key, n.size)
+	}
+	if balance := n.left.getHeight() - n.right.getHeight(); balance > 1 || balance < -1 {
+		t.Fatalf("node %v is unbalanced: %d", n.key, balance)
+	}
+	if n.left != nil && n.left.key >= n.key {
+		t.Fatalf("node %v has invalid left child %v", n.key, n.left.key)
+	}
+	if n.right != nil && n.right.key <= n.key {
+		t.Fatalf("node %v has invalid right child %v", n.key, n.right.key)
+	}
+	sortedTreeCheckInvariants(t, n.left)
+	sortedTreeCheckInvariants(t, n.right)
+}
+
+func Test_sortedTree_randomOperations(t *testing.T) {
+	rnd := rand.New(rand.NewSource(42))
+	tree := &sortedTree[int, int]{}
+	reference := map[int]int{}
+
+	for i := 0; i < 3000; i++ {
+		k := rnd.Intn(500)
+		if rnd.Intn(3) == 0 {
+			removed := tree.delete(k)
+			if _, ok := reference[k]; ok != (removed != nil) {
+				t.Fatalf("delete(%d) returned %v, but key presence was %v", k, removed, ok)
+			}
+			delete(reference, k)
+		} else {
+			_, existed := reference[k]
+			if inserted := tree.put(k, i); inserted == existed {
+				t.Fatalf("put(%d) returned %v, but key presence was %v", k, inserted, existed)
+			}
+			reference[k] = i
+		}
+	}
+
+	sortedTreeCheckInvariants(t, tree.root)
+
+	wantKeys := make([]int, 0, len(reference))
+	for k := range reference {
+		wantKeys = append(wantKeys, k)
+	}
+	slices.Sort(wantKeys)
+
+	if got := sortedTreeKeys(tree); !reflect.DeepEqual(got, wantKeys) {
+		t.Fatalf("ascend() = %v, want %v", got, wantKeys)
+	}
+	for i, k := range wantKeys {
+		if n := tree.at(i); n == nil || n.key != k {
+			t.Fatalf("at(%d) = %v, want %d", i, n, k)
+		}
+		if r := tree.rank(k); r != i {
+			t.Fatalf("rank(%d) = %d, want %d", k, r, i)
+		}
+		if n := tree.get(k); n == nil || n.val != reference[k] {
+			t.Fatalf("get(%d) = %v, want %d", k, n, reference[k])
+		}
+	}
+}
+
+func Test_sortedTree_search(t *testing.T) {
+	tree := &sortedTree[int, struct{}]{}
+	for _, k := range []int{10, 20, 30, 40} {
+		tree.put(k, struct{}{})
+	}
+
+	keyOf := func(n *sortedTreeNode[int, struct{}]) any {
+		if n == nil {
+			return nil
+		}
+		return n.key
+	}
+
+	cases := []struct {
+		name string
+		got  *sortedTreeNode[int, struct{}]
+		want any
+	}{
+		{name: "floor(25)", got: tree.floor(25), want: 20},
+		{name: "floor(20)", got: tree.floor(20), want: 20},
+		{name: "floor(5)", got: tree.floor(5), want: nil},
+		{name: "ceiling(25)", got: tree.ceiling(25), want: 30},
+		{name: "ceiling(30)", got: tree.ceiling(30), want: 30},
+		{name: "ceiling(45)", got: tree.ceiling(45), want: nil},
+		{name: "lower(20)", got: tree.lower(20), want: 10},
+		{name: "lower(10)", got: tree.lower(10), want: nil},
+		{name: "higher(20)", got: tree.higher(20), want: 30},
+		{name: "higher(40)", got: tree.higher(40), want: nil},
+		{name: "first()", got: tree.first(), want: 10},
+		{name: "last()", got: tree.last(), want: 40},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := keyOf(tt.got); got != tt.want {
+				t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_sortedTree_search_NaN(t *testing.T) {
+	tree := &sortedTree[float64, struct{}]{}
+	for _, k := range []float64{2, math.NaN(), 1} {
+		tree.put(k, struct{}{})
+	}
+
+	isNaN := func(n *sortedTreeNode[float64, struct{}]) bool { return n != nil && math.IsNaN(n.key) }
+	isKey := func(n *sortedTreeNode[float64, struct{}], k float64) bool { return n != nil && n.key == k }
+
+	cases := []struct {
+		name string
+		ok   bool
+	}{
+		{name: "floor(0)", ok: isNaN(tree.floor(0))},
+		{name: "floor(NaN)", ok: isNaN(tree.floor(math.NaN()))},
+		{name: "ceiling(NaN)", ok: isNaN(tree.ceiling(math.NaN()))},
+		{name: "lower(1)", ok: isNaN(tree.lower(1))},
+		{name: "lower(NaN)", ok: tree.lower(math.NaN()) == nil},
+		{name: "higher(NaN)", ok: isKey(tree.higher(math.NaN()), 1)},
+		{name: "ceiling(1.5)", ok: isKey(tree.ceiling(1.5), 2)},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.ok {
+				t.Errorf("%s returned a wrong node", tt.name)
+			}
+		})
+	}
+}
+
+func Test_sortedTree_rebuild(t *testing.T) {
+	tree := &sortedTree[int, int]{}
+	for i := range 100 {
+		tree.put(i, i)
+	}
+	nodes := tree.nodes()
+	kept := []*sortedTreeNode[int, int](nil)
+	for _, n := range nodes {
+		if n.key%3 == 0 {
+			kept = append(kept, n)
+		}
+	}
+	tree.rebuild(kept)
+
+	sortedTreeCheckInvariants(t, tree.root)
+	if tree.len() != 34 {
+		t.Errorf("len() after rebuild() = %d, want 34", tree.len())
+	}
+}
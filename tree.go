@@ -0,0 +1,305 @@
+package coll
+
+import (
+	"iter"
+)
+
+// Tree is a rooted n-ary tree of values.
+// Children of every node are kept in insertion order in a List.
+//
+// Len counts all nodes, and Values yields the values of all nodes in pre-order.
+type Tree[V any] interface {
+	Base[V]
+
+	// Clear removes all nodes, including the root.
+	Clear()
+
+	// Leaves returns an iterator over the nodes without children, from left to right.
+	Leaves() iter.Seq[TreeNode[V]]
+
+	// LevelOrder returns an iterator over all nodes in breadth-first order.
+	LevelOrder() iter.Seq[TreeNode[V]]
+
+	// PostOrder returns an iterator over all nodes, visiting children before their parent.
+	PostOrder() iter.Seq[TreeNode[V]]
+
+	// PreOrder returns an iterator over all nodes, visiting every parent before its children.
+	PreOrder() iter.Seq[TreeNode[V]]
+
+	// RemoveSubtree detaches the given node, together with all of its descendants, from the tree.
+	// Removing the root empties the tree.
+	// Returns the number of removed nodes, which is 0 if the node does not belong to this tree.
+	RemoveSubtree(n TreeNode[V]) (count int)
+
+	// Root returns the root node or nil if the tree is empty.
+	Root() TreeNode[V]
+
+	// SetRoot replaces the whole tree with a single root node holding the given value.
+	SetRoot(v V) TreeNode[V]
+}
+
+// TreeNode is a single node of a Tree.
+type TreeNode[V any] interface {
+	// AddChild appends a new child with the given value and returns it.
+	AddChild(v V) TreeNode[V]
+
+	// Children returns the children of the node, in insertion order.
+	// The returned collection is a copy: modifying it does not change the tree. Use AddChild and RemoveSubtree instead.
+	Children() Indexed[TreeNode[V]]
+
+	// Depth returns the number of edges between the node and the root.
+	Depth() int
+
+	// IsLeaf returns true if the node has no children.
+	IsLeaf() bool
+
+	// Parent returns the parent of the node or nil for the root.
+	Parent() TreeNode[V]
+
+	// Path returns the values of all nodes from the root down to this node.
+	Path() Sequence[V]
+
+	// SetVal sets the value of the node.
+	SetVal(v V)
+
+	// Val returns the value of the node.
+	Val() V
+}
+
+type comfyTreeNode[V any] struct {
+	val      V
+	tree     *comfyTree[V]
+	parent   *comfyTreeNode[V]
+	elem     *comfyListNode[TreeNode[V]]
+	children *comfyList[TreeNode[V]]
+}
+
+type comfyTree[V any] struct {
+	root *comfyTreeNode[V]
+	size int
+}
+
+// NewTree creates a new, empty Tree instance.
+func NewTree[V any]() Tree[V] {
+	return &comfyTree[V]{}
+}
+
+func (c *comfyTree[V]) Clear() {
+	if c.root != nil {
+		c.root.detach()
+	}
+	c.root = nil
+	c.size = 0
+}
+
+func (c *comfyTree[V]) IsEmpty() bool {
+	return c.size == 0
+}
+
+func (c *comfyTree[V]) Leaves() iter.Seq[TreeNode[V]] {
+	return func(yield func(TreeNode[V]) bool) {
+		c.root.preOrder(func(n *comfyTreeNode[V]) bool {
+			return !n.IsLeaf() || yield(n)
+		})
+	}
+}
+
+func (c *comfyTree[V]) Len() int {
+	return c.size
+}
+
+func (c *comfyTree[V]) LevelOrder() iter.Seq[TreeNode[V]] {
+	return func(yield func(TreeNode[V]) bool) {
+		if c.root == nil {
+			return
+		}
+		level := []*comfyTreeNode[V]{c.root}
+		for len(level) > 0 {
+			next := []*comfyTreeNode[V](nil)
+			for _, n := range level {
+				if !yield(n) {
+					return
+				}
+				for child := range n.children.Values() {
+					next = append(next, child.(*comfyTreeNode[V]))
+				}
+			}
+			level = next
+		}
+	}
+}
+
+func (c *comfyTree[V]) PostOrder() iter.Seq[TreeNode[V]] {
+	return func(yield func(TreeNode[V]) bool) {
+		c.root.postOrder(func(n *comfyTreeNode[V]) bool {
+			return yield(n)
+		})
+	}
+}
+
+func (c *comfyTree[V]) PreOrder() iter.Seq[TreeNode[V]] {
+	return func(yield func(TreeNode[V]) bool) {
+		c.root.preOrder(func(n *comfyTreeNode[V]) bool {
+			return yield(n)
+		})
+	}
+}
+
+func (c *comfyTree[V]) RemoveSubtree(n TreeNode[V]) (count int) {
+	node, ok := n.(*comfyTreeNode[V])
+	if !ok || node.tree != c {
+		return 0
+	}
+	if node == c.root {
+		count = c.size
+		c.Clear()
+		return count
+	}
+
+	node.parent.children.unlink(node.elem)
+	node.parent = nil
+	node.elem = nil
+	count = node.detach()
+	c.size -= count
+	return count
+}
+
+func (c *comfyTree[V]) Root() TreeNode[V] {
+	if c.root == nil {
+		return nil
+	}
+	return c.root
+}
+
+func (c *comfyTree[V]) SetRoot(v V) TreeNode[V] {
+	c.Clear()
+	c.root = c.newNode(v, nil)
+	c.size = 1
+	return c.root
+}
+
+func (c *comfyTree[V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		c.root.preOrder(func(n *comfyTreeNode[V]) bool {
+			return yield(n.val)
+		})
+	}
+}
+
+func (n *comfyTreeNode[V]) AddChild(v V) TreeNode[V] {
+	child := n.tree.newNode(v, n)
+	child.elem = n.children.pushBack(child)
+	if n.tree != nil {
+		n.tree.size++
+	}
+	return child
+}
+
+func (n *comfyTreeNode[V]) Children() Indexed[TreeNode[V]] {
+	children := &comfyList[TreeNode[V]]{}
+	for child := range n.children.Values() {
+		children.pushBack(child)
+	}
+	return children
+}
+
+func (n *comfyTreeNode[V]) Depth() int {
+	depth := 0
+	for p := n.parent; p != nil; p = p.parent {
+		depth++
+	}
+	return depth
+}
+
+func (n *comfyTreeNode[V]) IsLeaf() bool {
+	return n.children.IsEmpty()
+}
+
+func (n *comfyTreeNode[V]) Parent() TreeNode[V] {
+	if n.parent == nil {
+		return nil
+	}
+	return n.parent
+}
+
+func (n *comfyTreeNode[V]) Path() Sequence[V] {
+	path := make([]V, n.Depth()+1)
+	for i, p := len(path)-1, n; p != nil; i, p = i-1, p.parent {
+		path[i] = p.val
+	}
+	return NewSequenceFrom(path)
+}
+
+func (n *comfyTreeNode[V]) SetVal(v V) {
+	n.val = v
+}
+
+func (n *comfyTreeNode[V]) Val() V {
+	return n.val
+}
+
+// Private:
+
+//nolint:unused
+func (c *comfyTree[V]) copy() baseInternal[V] {
+	newTree := &comfyTree[V]{size: c.size}
+	if c.root != nil {
+		newTree.root = c.root.clone(newTree, nil)
+	}
+	return newTree
+}
+
+func (c *comfyTree[V]) newNode(v V, parent *comfyTreeNode[V]) *comfyTreeNode[V] {
+	return &comfyTreeNode[V]{
+		val:      v,
+		tree:     c,
+		parent:   parent,
+		children: &comfyList[TreeNode[V]]{},
+	}
+}
+
+func (n *comfyTreeNode[V]) clone(tree *comfyTree[V], parent *comfyTreeNode[V]) *comfyTreeNode[V] {
+	newNode := tree.newNode(n.val, parent)
+	for child := range n.children.Values() {
+		childClone := child.(*comfyTreeNode[V]).clone(tree, newNode)
+		childClone.elem = newNode.children.pushBack(childClone)
+	}
+	return newNode
+}
+
+// detach disconnects the subtree from its tree and returns the number of its nodes.
+func (n *comfyTreeNode[V]) detach() (count int) {
+	n.preOrder(func(d *comfyTreeNode[V]) bool {
+		d.tree = nil
+		count++
+		return true
+	})
+	return count
+}
+
+func (n *comfyTreeNode[V]) postOrder(visit func(n *comfyTreeNode[V]) bool) bool {
+	if n == nil {
+		return true
+	}
+	for child := range n.children.Values() {
+		if !child.(*comfyTreeNode[V]).postOrder(visit) {
+			return false
+		}
+	}
+	return visit(n)
+}
+
+func (n *comfyTreeNode[V]) preOrder(visit func(n *comfyTreeNode[V]) bool) bool {
+	if n == nil {
+		return true
+	}
+	if !visit(n) {
+		return false
+	}
+	for child := range n.children.Values() {
+		if !child.(*comfyTreeNode[V]).preOrder(visit) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,249 @@
+package coll
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// treeSample builds the tree:
+//
+//	a
+//	├── b
+//	│   ├── d
+//	│   └── e
+//	│       └── g
+//	└── c
+//	    └── f
+func treeSample() (Tree[string], map[string]TreeNode[string]) {
+	tr := NewTree[string]()
+	nodes := map[string]TreeNode[string]{}
+	nodes["a"] = tr.SetRoot("a")
+	nodes["b"] = nodes["a"].AddChild("b")
+	nodes["c"] = nodes["a"].AddChild("c")
+	nodes["d"] = nodes["b"].AddChild("d")
+	nodes["e"] = nodes["b"].AddChild("e")
+	nodes["f"] = nodes["c"].AddChild("f")
+	nodes["g"] = nodes["e"].AddChild("g")
+	return tr, nodes
+}
+
+func treeNodeVals(seq func(func(TreeNode[string]) bool)) []string {
+	vals := []string(nil)
+	for n := range seq {
+		vals = append(vals, n.Val())
+	}
+	return vals
+}
+
+func TestNewTree(t *testing.T) {
+	tr := NewTree[int]()
+	if tr == nil {
+		t.Fatal("NewTree[int]() returned nil")
+	}
+	if !reflect.DeepEqual(tr, &comfyTree[int]{}) {
+		t.Error("NewTree[int]() did not return an empty comfyTree[int]")
+	}
+	if tr.Root() != nil || !tr.IsEmpty() || tr.Len() != 0 {
+		t.Error("NewTree[int]() is not empty")
+	}
+	if got := treeNodeVals(NewTree[string]().PreOrder()); got != nil {
+		t.Errorf("PreOrder() on empty tree = %v", got)
+	}
+}
+
+func Test_comfyTree_traversals(t *testing.T) {
+	tr, _ := treeSample()
+
+	cases := []struct {
+		name string
+		got  []string
+		want []string
+	}{
+		{name: "PreOrder()", got: treeNodeVals(tr.PreOrder()), want: []string{"a", "b", "d", "e", "g", "c", "f"}},
+		{name: "PostOrder()", got: treeNodeVals(tr.PostOrder()), want: []string{"d", "g", "e", "b", "f", "c", "a"}},
+		{name: "LevelOrder()", got: treeNodeVals(tr.LevelOrder()), want: []string{"a", "b", "c", "d", "e", "f", "g"}},
+		{name: "Leaves()", got: treeNodeVals(tr.Leaves()), want: []string{"d", "g", "f"}},
+		{name: "Values()", got: slices.Collect(tr.Values()), want: []string{"a", "b", "d", "e", "g", "c", "f"}},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_comfyTree_traversalsBreak(t *testing.T) {
+	tr, _ := treeSample()
+
+	cases := []struct {
+		name string
+		seq  func(func(TreeNode[string]) bool)
+		want []string
+	}{
+		{name: "PreOrder()", seq: tr.PreOrder(), want: []string{"a", "b", "d"}},
+		{name: "PostOrder()", seq: tr.PostOrder(), want: []string{"d", "g", "e"}},
+		{name: "LevelOrder()", seq: tr.LevelOrder(), want: []string{"a", "b", "c"}},
+		{name: "Leaves()", seq: tr.Leaves(), want: []string{"d", "g", "f"}},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string(nil)
+			for n := range tt.seq {
+				got = append(got, n.Val())
+				if len(got) == 3 {
+					break
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%s with break = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_comfyTreeNode(t *testing.T) {
+	tr, nodes := treeSample()
+
+	if tr.Len() != 7 {
+		t.Errorf("Len() = %d, want 7", tr.Len())
+	}
+	if tr.Root() != nodes["a"] || nodes["a"].Parent() != nil {
+		t.Error("Root() is not the parentless first node")
+	}
+	if nodes["g"].Parent() != nodes["e"] {
+		t.Error("Parent() of g is not e")
+	}
+
+	depths := map[string]int{"a": 0, "b": 1, "c": 1, "e": 2, "g": 3}
+	for v, want := range depths {
+		if got := nodes[v].Depth(); got != want {
+			t.Errorf("Depth() of %s = %d, want %d", v, got, want)
+		}
+	}
+
+	if got := slices.Collect(nodes["g"].Path().Values()); !reflect.DeepEqual(got, []string{"a", "b", "e", "g"}) {
+		t.Errorf("Path() of g = %v", got)
+	}
+	if got := slices.Collect(nodes["a"].Path().Values()); !reflect.DeepEqual(got, []string{"a"}) {
+		t.Errorf("Path() of root = %v", got)
+	}
+
+	children := nodes["b"].Children()
+	if children.Len() != 2 {
+		t.Errorf("Children().Len() of b = %d, want 2", children.Len())
+	}
+	if second, _ := children.At(1); second != nodes["e"] {
+		t.Error("Children().At(1) of b is not e")
+	}
+	children.(List[TreeNode[string]]).Clear()
+	if nodes["b"].Children().Len() != 2 || tr.Len() != 7 {
+		t.Error("modifying Children() changed the tree")
+	}
+	if !nodes["d"].IsLeaf() || nodes["b"].IsLeaf() {
+		t.Error("IsLeaf() returned wrong result")
+	}
+
+	nodes["d"].SetVal("D")
+	if nodes["d"].Val() != "D" {
+		t.Errorf("Val() after SetVal() = %v", nodes["d"].Val())
+	}
+}
+
+func Test_comfyTree_RemoveSubtree(t *testing.T) {
+	t.Run("RemoveSubtree() of an inner node", func(t *testing.T) {
+		tr, nodes := treeSample()
+		if count := tr.RemoveSubtree(nodes["b"]); count != 4 {
+			t.Errorf("RemoveSubtree(b) = %d, want 4", count)
+		}
+		if tr.Len() != 3 {
+			t.Errorf("Len() = %d, want 3", tr.Len())
+		}
+		if got := treeNodeVals(tr.PreOrder()); !reflect.DeepEqual(got, []string{"a", "c", "f"}) {
+			t.Errorf("PreOrder() after RemoveSubtree() = %v", got)
+		}
+		if nodes["b"].Parent() != nil {
+			t.Error("removed node still has a parent")
+		}
+		if count := tr.RemoveSubtree(nodes["e"]); count != 0 {
+			t.Errorf("RemoveSubtree() of an already removed node = %d, want 0", count)
+		}
+	})
+
+	t.Run("RemoveSubtree() of a leaf", func(t *testing.T) {
+		tr, nodes := treeSample()
+		if count := tr.RemoveSubtree(nodes["f"]); count != 1 {
+			t.Errorf("RemoveSubtree(f) = %d, want 1", count)
+		}
+		if !nodes["c"].IsLeaf() || tr.Len() != 6 {
+			t.Error("RemoveSubtree() did not unlink the leaf")
+		}
+	})
+
+	t.Run("RemoveSubtree() of the root", func(t *testing.T) {
+		tr, nodes := treeSample()
+		if count := tr.RemoveSubtree(nodes["a"]); count != 7 {
+			t.Errorf("RemoveSubtree(a) = %d, want 7", count)
+		}
+		if !tr.IsEmpty() || tr.Root() != nil {
+			t.Error("RemoveSubtree() of the root did not empty the tree")
+		}
+	})
+
+	t.Run("RemoveSubtree() of a node from another tree", func(t *testing.T) {
+		tr, _ := treeSample()
+		_, other := treeSample()
+		if count := tr.RemoveSubtree(other["b"]); count != 0 || tr.Len() != 7 {
+			t.Errorf("RemoveSubtree() of a foreign node = %d", count)
+		}
+	})
+
+	t.Run("AddChild() on a removed node does not change the tree", func(t *testing.T) {
+		tr, nodes := treeSample()
+		tr.RemoveSubtree(nodes["b"])
+		nodes["b"].AddChild("x")
+		if tr.Len() != 3 {
+			t.Errorf("Len() = %d, want 3", tr.Len())
+		}
+	})
+}
+
+func Test_comfyTree_SetRootAndClear(t *testing.T) {
+	tr, nodes := treeSample()
+
+	root := tr.SetRoot("z")
+	if tr.Len() != 1 || tr.Root() != root {
+		t.Error("SetRoot() did not replace the tree")
+	}
+	if count := tr.RemoveSubtree(nodes["b"]); count != 0 {
+		t.Error("nodes of the replaced tree still belong to it")
+	}
+
+	tr.Clear()
+	if !tr.IsEmpty() || tr.Root() != nil {
+		t.Error("Clear() did not empty the tree")
+	}
+}
+
+func Test_comfyTree_copy(t *testing.T) {
+	c1, nodes := treeSample()
+	c2 := Copy(c1)
+
+	if got := treeNodeVals(c2.PreOrder()); !reflect.DeepEqual(got, treeNodeVals(c1.PreOrder())) {
+		t.Errorf("Copy() PreOrder() = %v", got)
+	}
+	if c2.Len() != c1.Len() {
+		t.Errorf("Copy() Len() = %d, want %d", c2.Len(), c1.Len())
+	}
+
+	nodes["g"].AddChild("h")
+	nodes["a"].SetVal("A")
+	if c2.Len() != 7 || c2.Root().Val() != "a" {
+		t.Error("copy() did not create a deep copy")
+	}
+	if count := c2.RemoveSubtree(c2.Root()); count != 7 {
+		t.Errorf("copied nodes do not belong to the copy, RemoveSubtree() = %d", count)
+	}
+}
@@ -0,0 +1,400 @@
+package coll
+
+import (
+	"iter"
+	"slices"
+)
+
+// Trie is a Map with string keys that are stored in a prefix tree.
+// Keys are kept in lexicographic (byte-wise) order and can be queried by prefix
+// in time proportional to the length of the prefix.
+//
+// Since the order of keys is defined by the keys themselves, Append, Prepend and SetMany are equivalent,
+// and Reverse and Sort are no-ops.
+type Trie[V any] interface {
+	Map[string, V]
+
+	// CountPrefix returns the number of keys that start with the given prefix.
+	CountPrefix(p string) int
+
+	// LongestPrefixOf returns the pair with the longest key that is a prefix of the given string.
+	LongestPrefixOf(s string) (Pair[string, V], bool)
+
+	// RemovePrefix removes all keys that start with the given prefix.
+	// Returns the number of removed keys.
+	RemovePrefix(p string) (count int)
+
+	// WithPrefix returns an iterator over all key-value pairs whose key starts with the given prefix,
+	// in lexicographic order.
+	WithPrefix(p string) iter.Seq2[string, V]
+}
+
+type trieNode[V any] struct {
+	label    byte
+	children []*trieNode[V]
+	pair     Pair[string, V]
+	count    int
+}
+
+type comfyTrie[V any] struct {
+	root *trieNode[V]
+}
+
+// NewTrie creates a new Trie instance.
+func NewTrie[V any]() Trie[V] {
+	return &comfyTrie[V]{
+		root: &trieNode[V]{},
+	}
+}
+
+// NewTrieFrom creates a new Trie instance from a slice of pairs.
+func NewTrieFrom[V any](s []Pair[string, V]) Trie[V] {
+	t := NewTrie[V]()
+	t.SetMany(s)
+	return t
+}
+
+func (c *comfyTrie[V]) Append(p ...Pair[string, V]) {
+	c.SetMany(p)
+}
+
+func (c *comfyTrie[V]) AppendColl(coll Ordered[Pair[string, V]]) {
+	c.SetMany(slices.Collect(coll.Values()))
+}
+
+func (c *comfyTrie[V]) Apply(f Mapper[Pair[string, V]]) {
+	mapped := make([]Pair[string, V], 0, c.root.count)
+	for pair := range c.Values() {
+		mapped = append(mapped, f(pair))
+	}
+	c.Clear()
+	c.SetMany(mapped)
+}
+
+func (c *comfyTrie[V]) At(i int) (p Pair[string, V], found bool) {
+	if i < 0 || i >= c.root.count {
+		return nil, false
+	}
+	n := c.root
+	for {
+		if n.pair != nil {
+			if i == 0 {
+				return n.pair, true
+			}
+			i--
+		}
+		for _, child := range n.children {
+			if i < child.count {
+				n = child
+				break
+			}
+			i -= child.count
+		}
+	}
+}
+
+func (c *comfyTrie[V]) AtOrDefault(i int, defaultValue Pair[string, V]) Pair[string, V] {
+	if p, found := c.At(i); found {
+		return p
+	}
+	return defaultValue
+}
+
+func (c *comfyTrie[V]) Clear() {
+	c.root = &trieNode[V]{}
+}
+
+func (c *comfyTrie[V]) CountPrefix(p string) int {
+	if n := c.find(p); n != nil {
+		return n.count
+	}
+	return 0
+}
+
+func (c *comfyTrie[V]) Get(k string) (V, bool) {
+	n := c.find(k)
+	if n == nil || n.pair == nil {
+		var v V
+		return v, false
+	}
+	return n.pair.Val(), true
+}
+
+func (c *comfyTrie[V]) GetOrDefault(k string, defaultValue V) V {
+	if v, ok := c.Get(k); ok {
+		return v
+	}
+	return defaultValue
+}
+
+func (c *comfyTrie[V]) Has(k string) bool {
+	n := c.find(k)
+	return n != nil && n.pair != nil
+}
+
+func (c *comfyTrie[V]) IsEmpty() bool {
+	return c.root.count == 0
+}
+
+func (c *comfyTrie[V]) Keys() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		c.root.ascend(func(pair Pair[string, V]) bool {
+			return yield(pair.Key())
+		})
+	}
+}
+
+func (c *comfyTrie[V]) KeyValues() iter.Seq2[string, V] {
+	return c.WithPrefix("")
+}
+
+func (c *comfyTrie[V]) Len() int {
+	return c.root.count
+}
+
+func (c *comfyTrie[V]) LongestPrefixOf(s string) (Pair[string, V], bool) {
+	var longest Pair[string, V]
+	n := c.root
+	for i := 0; n != nil; i++ {
+		if n.pair != nil {
+			longest = n.pair
+		}
+		if i == len(s) {
+			break
+		}
+		n = n.child(s[i])
+	}
+	return longest, longest != nil
+}
+
+func (c *comfyTrie[V]) Prepend(p ...Pair[string, V]) {
+	c.SetMany(p)
+}
+
+func (c *comfyTrie[V]) Remove(k string) {
+	path := c.path(k)
+	if path == nil || path[len(path)-1].pair == nil {
+		return
+	}
+	path[len(path)-1].pair = nil
+	c.detach(path, 1)
+}
+
+func (c *comfyTrie[V]) RemoveAt(idx int) (removed Pair[string, V], err error) {
+	removed, found := c.At(idx)
+	if !found {
+		return removed, ErrOutOfBounds
+	}
+	c.Remove(removed.Key())
+	return removed, nil
+}
+
+func (c *comfyTrie[V]) RemoveMany(keys []string) {
+	for _, k := range keys {
+		c.Remove(k)
+	}
+}
+
+func (c *comfyTrie[V]) RemoveMatching(predicate Predicate[Pair[string, V]]) (count int) {
+	keys := []string(nil)
+	for pair := range c.Values() {
+		if predicate(pair) {
+			keys = append(keys, pair.Key())
+		}
+	}
+	c.RemoveMany(keys)
+	return len(keys)
+}
+
+func (c *comfyTrie[V]) RemovePrefix(p string) (count int) {
+	path := c.path(p)
+	if path == nil {
+		return 0
+	}
+	n := path[len(path)-1]
+	count = n.count
+	if n == c.root {
+		c.Clear()
+		return count
+	}
+	n.pair = nil
+	n.children = nil
+	c.detach(path, count)
+	return count
+}
+
+// Reverse is a no-op, because keys of a Trie are always kept in lexicographic order.
+func (c *comfyTrie[V]) Reverse() {
+}
+
+func (c *comfyTrie[V]) Set(k string, v V) {
+	c.set(NewPair(k, v))
+}
+
+func (c *comfyTrie[V]) SetMany(s []Pair[string, V]) {
+	for _, pair := range s {
+		c.set(pair)
+	}
+}
+
+// Sort is a no-op, because keys of a Trie are always kept in lexicographic order.
+func (c *comfyTrie[V]) Sort(_ PairComparator[string, V]) {
+}
+
+func (c *comfyTrie[V]) Values() iter.Seq[Pair[string, V]] {
+	return func(yield func(Pair[string, V]) bool) {
+		c.root.ascend(yield)
+	}
+}
+
+func (c *comfyTrie[V]) ValuesRev() iter.Seq[Pair[string, V]] {
+	return func(yield func(Pair[string, V]) bool) {
+		c.root.descend(yield)
+	}
+}
+
+func (c *comfyTrie[V]) WithPrefix(p string) iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		n := c.find(p)
+		if n == nil {
+			return
+		}
+		n.ascend(func(pair Pair[string, V]) bool {
+			return yield(pair.Key(), pair.Val())
+		})
+	}
+}
+
+// Private:
+
+//nolint:unused
+func (c *comfyTrie[V]) copy() baseInternal[Pair[string, V]] {
+	return &comfyTrie[V]{
+		root: c.root.clone(),
+	}
+}
+
+// detach decrements counts along the path by the given number of removed keys
+// and prunes nodes that no longer hold any keys.
+func (c *comfyTrie[V]) detach(path []*trieNode[V], removed int) {
+	for i := len(path) - 1; i >= 0; i-- {
+		n := path[i]
+		n.count -= removed
+		if i > 0 && n.count == 0 {
+			path[i-1].removeChild(n.label)
+		}
+	}
+}
+
+// find returns the node for the given key or prefix, or nil if there is none.
+func (c *comfyTrie[V]) find(k string) *trieNode[V] {
+	n := c.root
+	for i := 0; i < len(k) && n != nil; i++ {
+		n = n.child(k[i])
+	}
+	return n
+}
+
+// path returns all nodes from the root to the node for the given key or prefix,
+// or nil if there is no such node.
+func (c *comfyTrie[V]) path(k string) []*trieNode[V] {
+	path := make([]*trieNode[V], 0, len(k)+1)
+	n := c.root
+	path = append(path, n)
+	for i := 0; i < len(k); i++ {
+		if n = n.child(k[i]); n == nil {
+			return nil
+		}
+		path = append(path, n)
+	}
+	return path
+}
+
+func (c *comfyTrie[V]) set(pair Pair[string, V]) {
+	k := pair.Key()
+	if n := c.find(k); n != nil && n.pair != nil {
+		n.pair = pair
+		return
+	}
+
+	n := c.root
+	n.count++
+	for i := 0; i < len(k); i++ {
+		n = n.childOrCreate(k[i])
+		n.count++
+	}
+	n.pair = pair
+}
+
+// ascend visits all pairs in the subtree in lexicographic order.
+func (n *trieNode[V]) ascend(visit func(Pair[string, V]) bool) bool {
+	if n.pair != nil && !visit(n.pair) {
+		return false
+	}
+	for _, child := range n.children {
+		if !child.ascend(visit) {
+			return false
+		}
+	}
+	return true
+}
+
+// descend visits all pairs in the subtree in reverse lexicographic order.
+func (n *trieNode[V]) descend(visit func(Pair[string, V]) bool) bool {
+	for i := len(n.children) - 1; i >= 0; i-- {
+		if !n.children[i].descend(visit) {
+			return false
+		}
+	}
+	return n.pair == nil || visit(n.pair)
+}
+
+func (n *trieNode[V]) child(label byte) *trieNode[V] {
+	if i, found := n.search(label); found {
+		return n.children[i]
+	}
+	return nil
+}
+
+func (n *trieNode[V]) childOrCreate(label byte) *trieNode[V] {
+	i, found := n.search(label)
+	if found {
+		return n.children[i]
+	}
+	child := &trieNode[V]{label: label}
+	n.children = slices.Insert(n.children, i, child)
+	return child
+}
+
+func (n *trieNode[V]) clone() *trieNode[V] {
+	newNode := &trieNode[V]{
+		label: n.label,
+		count: n.count,
+	}
+	if n.pair != nil {
+		newNode.pair = n.pair.copy()
+	}
+	if n.children != nil {
+		newNode.children = make([]*trieNode[V], len(n.children))
+		for i, child := range n.children {
+			newNode.children[i] = child.clone()
+		}
+	}
+	return newNode
+}
+
+func (n *trieNode[V]) removeChild(label byte) {
+	if i, found := n.search(label); found {
+		n.children = slices.Delete(n.children, i, i+1)
+	}
+	if len(n.children) == 0 {
+		n.children = nil
+	}
+}
+
+// search returns the position of the child with the given label, or the position where it would be inserted.
+func (n *trieNode[V]) search(label byte) (int, bool) {
+	return slices.BinarySearchFunc(n.children, label, func(child *trieNode[V], label byte) int {
+		return int(child.label) - int(label)
+	})
+}
@@ -0,0 +1,345 @@
+package coll
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func trieKVs(seq func(func(string, int) bool)) [][2]any {
+	kvs := [][2]any(nil)
+	for k, v := range seq {
+		kvs = append(kvs, [2]any{k, v})
+	}
+	return kvs
+}
+
+// trieCheckCounts verifies that every node holds the number of keys in its subtree and that no empty nodes are left.
+func trieCheckCounts[V any](t *testing.T, n *trieNode[V], isRoot bool) int {
+	t.Helper()
+	count := 0
+	if n.pair != nil {
+		count++
+	}
+	for i, child := range n.children {
+		if i > 0 && n.children[i-1].label >= child.label {
+			t.Fatalf("children of node %q are not sorted", n.label)
+		}
+		count += trieCheckCounts(t, child, false)
+	}
+	if count != n.count {
+		t.Fatalf("node %q has count %d, want %d", n.label, n.count, count)
+	}
+	if !isRoot && count == 0 {
+		t.Fatalf("node %q holds no keys and was not pruned", n.label)
+	}
+	return count
+}
+
+func TestNewTrie(t *testing.T) {
+	tr := NewTrie[int]()
+	if tr == nil {
+		t.Fatal("NewTrie[int]() returned nil")
+	}
+	if !reflect.DeepEqual(tr, &comfyTrie[int]{root: &trieNode[int]{}}) {
+		t.Error("NewTrie[int]() did not return an empty comfyTrie[int]")
+	}
+}
+
+func TestNewTrieFrom(t *testing.T) {
+	tr := NewTrieFrom([]Pair[string, int]{
+		NewPair("tea", 1),
+		NewPair("ten", 2),
+		NewPair("", 0),
+		NewPair("to", 3),
+		NewPair("tea", 4),
+	})
+	want := [][2]any{{"", 0}, {"tea", 4}, {"ten", 2}, {"to", 3}}
+	if got := trieKVs(tr.KeyValues()); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewTrieFrom() = %v, want %v", got, want)
+	}
+	if tr.Len() != 4 {
+		t.Errorf("Len() = %d, want 4", tr.Len())
+	}
+}
+
+func Test_comfyTrie_Get(t *testing.T) {
+	tr := NewTrieFrom([]Pair[string, int]{NewPair("tea", 1), NewPair("team", 2)})
+
+	cases := []struct {
+		key    string
+		want   int
+		wantOk bool
+	}{
+		{key: "tea", want: 1, wantOk: true},
+		{key: "team", want: 2, wantOk: true},
+		{key: "te", want: 0, wantOk: false},
+		{key: "teams", want: 0, wantOk: false},
+		{key: "", want: 0, wantOk: false},
+	}
+	for _, tt := range cases {
+		t.Run("Get("+tt.key+")", func(t *testing.T) {
+			if v, ok := tr.Get(tt.key); v != tt.want || ok != tt.wantOk {
+				t.Errorf("Get(%q) = %v, %v, want %v, %v", tt.key, v, ok, tt.want, tt.wantOk)
+			}
+			if has := tr.Has(tt.key); has != tt.wantOk {
+				t.Errorf("Has(%q) = %v, want %v", tt.key, has, tt.wantOk)
+			}
+		})
+	}
+	if v := tr.GetOrDefault("x", -1); v != -1 {
+		t.Errorf("GetOrDefault(x) = %v, want -1", v)
+	}
+}
+
+func Test_comfyTrie_At(t *testing.T) {
+	keys := []string{"b", "a", "abc", "ab", "ba", "c", ""}
+	tr := NewTrie[int]()
+	for i, k := range keys {
+		tr.Set(k, i)
+	}
+	slices.Sort(keys)
+
+	for i, k := range keys {
+		if p, ok := tr.At(i); !ok || p.Key() != k {
+			t.Errorf("At(%d) = %v, %v, want key %q", i, p, ok, k)
+		}
+	}
+	if p, ok := tr.At(len(keys)); ok || p != nil {
+		t.Errorf("At(%d) = %v, %v, want nil, false", len(keys), p, ok)
+	}
+	if p, ok := tr.At(-1); ok || p != nil {
+		t.Errorf("At(-1) = %v, %v, want nil, false", p, ok)
+	}
+	if def := NewPair("x", 0); tr.AtOrDefault(100, def) != def {
+		t.Error("AtOrDefault(100) did not return the default value")
+	}
+}
+
+func Test_comfyTrie_prefixQueries(t *testing.T) {
+	tr := NewTrieFrom([]Pair[string, int]{
+		NewPair("/", 0),
+		NewPair("/api", 1),
+		NewPair("/api/users", 2),
+		NewPair("/api/users/me", 3),
+		NewPair("/apis", 4),
+		NewPair("/static", 5),
+	})
+
+	t.Run("WithPrefix()", func(t *testing.T) {
+		want := [][2]any{{"/api/users", 2}, {"/api/users/me", 3}}
+		if got := trieKVs(tr.WithPrefix("/api/")); !reflect.DeepEqual(got, want) {
+			t.Errorf("WithPrefix(/api/) = %v, want %v", got, want)
+		}
+		if got := trieKVs(tr.WithPrefix("/x")); got != nil {
+			t.Errorf("WithPrefix(/x) = %v, want nothing", got)
+		}
+		count := 0
+		for range tr.WithPrefix("/") {
+			count++
+			if count == 2 {
+				break
+			}
+		}
+		if count != 2 {
+			t.Errorf("WithPrefix() did not stop, count = %d", count)
+		}
+	})
+
+	t.Run("CountPrefix()", func(t *testing.T) {
+		cases := map[string]int{"": 6, "/": 6, "/api": 4, "/api/": 2, "/s": 1, "/x": 0, "/static/x": 0}
+		for p, want := range cases {
+			if got := tr.CountPrefix(p); got != want {
+				t.Errorf("CountPrefix(%q) = %d, want %d", p, got, want)
+			}
+		}
+	})
+
+	t.Run("LongestPrefixOf()", func(t *testing.T) {
+		cases := []struct {
+			s      string
+			want   string
+			wantOk bool
+		}{
+			{s: "/api/users/42", want: "/api/users", wantOk: true},
+			{s: "/api/users/me", want: "/api/users/me", wantOk: true},
+			{s: "/apix", want: "/api", wantOk: true},
+			{s: "/favicon.ico", want: "/", wantOk: true},
+			{s: "api", wantOk: false},
+			{s: "", wantOk: false},
+		}
+		for _, tt := range cases {
+			p, ok := tr.LongestPrefixOf(tt.s)
+			if ok != tt.wantOk || (ok && p.Key() != tt.want) {
+				t.Errorf("LongestPrefixOf(%q) = %v, %v, want %q, %v", tt.s, p, ok, tt.want, tt.wantOk)
+			}
+		}
+	})
+}
+
+func Test_comfyTrie_RemovePrefix(t *testing.T) {
+	tr := NewTrieFrom([]Pair[string, int]{
+		NewPair("a", 1),
+		NewPair("ab", 2),
+		NewPair("abc", 3),
+		NewPair("abd", 4),
+		NewPair("b", 5),
+	})
+
+	if count := tr.RemovePrefix("abx"); count != 0 {
+		t.Errorf("RemovePrefix(abx) = %d, want 0", count)
+	}
+	if count := tr.RemovePrefix("ab"); count != 3 {
+		t.Errorf("RemovePrefix(ab) = %d, want 3", count)
+	}
+	trieCheckCounts(t, tr.(*comfyTrie[int]).root, true)
+	if got := slices.Collect(tr.Keys()); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("Keys() after RemovePrefix() = %v", got)
+	}
+	if count := tr.RemovePrefix(""); count != 2 || !tr.IsEmpty() {
+		t.Errorf("RemovePrefix(\"\") = %d, IsEmpty() = %v", count, tr.IsEmpty())
+	}
+}
+
+func Test_comfyTrie_Remove(t *testing.T) {
+	tr := NewTrieFrom([]Pair[string, int]{
+		NewPair("a", 1),
+		NewPair("ab", 2),
+		NewPair("abc", 3),
+		NewPair("b", 4),
+	})
+
+	tr.Remove("ab")
+	tr.Remove("abcd")
+	tr.Remove("x")
+	trieCheckCounts(t, tr.(*comfyTrie[int]).root, true)
+	if got := slices.Collect(tr.Keys()); !reflect.DeepEqual(got, []string{"a", "abc", "b"}) {
+		t.Errorf("Keys() after Remove() = %v", got)
+	}
+
+	tr.RemoveMany([]string{"abc", "b"})
+	removed, err := tr.RemoveAt(0)
+	if err != nil || removed.Key() != "a" {
+		t.Errorf("RemoveAt(0) = %v, %v, want key a", removed, err)
+	}
+	if _, err = tr.RemoveAt(0); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("RemoveAt(0) on empty trie returned error %v, want ErrOutOfBounds", err)
+	}
+	if !reflect.DeepEqual(tr, NewTrie[int]()) {
+		t.Errorf("removing all keys did not prune all nodes: %+v", tr.(*comfyTrie[int]).root)
+	}
+}
+
+func Test_comfyTrie_randomOperations(t *testing.T) {
+	rnd := rand.New(rand.NewSource(42))
+	tr := NewTrie[int]()
+	reference := map[string]int{}
+	randomKey := func() string {
+		var sb strings.Builder
+		for range rnd.Intn(5) {
+			sb.WriteByte("abc"[rnd.Intn(3)])
+		}
+		return sb.String()
+	}
+
+	for i := range 3000 {
+		k := randomKey()
+		switch rnd.Intn(5) {
+		case 0:
+			tr.Remove(k)
+			delete(reference, k)
+		case 1:
+			if rnd.Intn(10) == 0 {
+				tr.RemovePrefix(k)
+				for rk := range reference {
+					if strings.HasPrefix(rk, k) {
+						delete(reference, rk)
+					}
+				}
+			}
+		default:
+			tr.Set(k, i)
+			reference[k] = i
+		}
+	}
+
+	trieCheckCounts(t, tr.(*comfyTrie[int]).root, true)
+	wantKeys := make([]string, 0, len(reference))
+	for k := range reference {
+		wantKeys = append(wantKeys, k)
+	}
+	slices.Sort(wantKeys)
+	if got := slices.Collect(tr.Keys()); !reflect.DeepEqual(got, wantKeys) {
+		t.Fatalf("Keys() = %v, want %v", got, wantKeys)
+	}
+	for i, k := range wantKeys {
+		if p, ok := tr.At(i); !ok || p.Key() != k || p.Val() != reference[k] {
+			t.Fatalf("At(%d) = %v, %v, want (%q, %d)", i, p, ok, k, reference[k])
+		}
+	}
+}
+
+func Test_comfyTrie_mutations(t *testing.T) {
+	tr := NewTrieFrom([]Pair[string, int]{NewPair("b", 2), NewPair("a", 1), NewPair("c", 3)})
+
+	tr.Append(NewPair("d", 4))
+	tr.Prepend(NewPair("0", 0))
+	tr.AppendColl(NewMapFrom([]Pair[string, int]{NewPair("e", 5)}))
+	tr.Reverse()
+	tr.Sort(func(a, b Pair[string, int]) int { return b.Val() - a.Val() })
+	if got := slices.Collect(tr.Keys()); !reflect.DeepEqual(got, []string{"0", "a", "b", "c", "d", "e"}) {
+		t.Errorf("Keys() = %v", got)
+	}
+
+	keys := []string(nil)
+	for p := range tr.ValuesRev() {
+		keys = append(keys, p.Key())
+	}
+	if !reflect.DeepEqual(keys, []string{"e", "d", "c", "b", "a", "0"}) {
+		t.Errorf("ValuesRev() keys = %v", keys)
+	}
+
+	if count := tr.RemoveMatching(func(p Pair[string, int]) bool { return p.Val()%2 == 0 }); count != 3 {
+		t.Errorf("RemoveMatching() = %d, want 3", count)
+	}
+	tr.Apply(func(p Pair[string, int]) Pair[string, int] {
+		return NewPair(strings.ToUpper(p.Key()), p.Val()*10)
+	})
+	if got, want := trieKVs(tr.KeyValues()), [][2]any{{"A", 10}, {"C", 30}, {"E", 50}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Apply() resulted in: %v, but wanted %v", got, want)
+	}
+	trieCheckCounts(t, tr.(*comfyTrie[int]).root, true)
+
+	for p := range tr.Values() {
+		p.SetVal(-1)
+	}
+	if v, _ := tr.Get("A"); v != -1 {
+		t.Errorf("Values() did not yield the stored pairs, Get(A) = %v", v)
+	}
+
+	tr.Clear()
+	if !tr.IsEmpty() || tr.Len() != 0 {
+		t.Error("Clear() did not remove all pairs")
+	}
+}
+
+func Test_comfyTrie_copy(t *testing.T) {
+	c1 := NewTrieFrom([]Pair[string, int]{NewPair("a", 1), NewPair("ab", 2)})
+	c2 := Copy(c1)
+
+	if !reflect.DeepEqual(c1, c2) {
+		t.Errorf("Copy() = %v, want %v", c2, c1)
+	}
+	p, _ := c1.At(0)
+	p.SetVal(100)
+	c1.Set("abc", 3)
+	if v, _ := c2.Get("a"); v != 1 {
+		t.Error("copy() did not copy pairs")
+	}
+	if c2.Has("abc") || c2.Len() != 2 {
+		t.Error("copy() did not create a deep copy")
+	}
+}
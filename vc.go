@@ -1,14 +1,10 @@
 package coll
 
-import (
-	"cmp"
-)
-
-type valuesCounter[V cmp.Ordered] struct {
+type valuesCounter[V comparable] struct {
 	counter map[V]int
 }
 
-func newValuesCounter[V cmp.Ordered]() *valuesCounter[V] {
+func newValuesCounter[V comparable]() *valuesCounter[V] {
 	return &valuesCounter[V]{
 		counter: make(map[V]int),
 	}